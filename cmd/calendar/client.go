@@ -0,0 +1,110 @@
+package main
+
+import (
+	pb "calendar/internal/proto"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc"
+	"os"
+	"time"
+)
+
+const clientUsage = `usage: calendar client [flags] <action>
+
+actions:
+  insert  create event
+  update  replace event -uuid
+  remove  remove event -uuid
+  day     events of the day of -date
+  week    events of the week starting at -date
+  month   events of the month starting at -date
+
+flags:
+`
+
+//runClient - консольный клиент API для ручной проверки
+func runClient(shared *sharedFlags, args []string) error {
+	fs := flag.NewFlagSet("client", flag.ExitOnError)
+	shared.register(fs)
+	address := fs.String("address", "localhost:50051", "API address")
+	timeout := fs.Duration("timeout", 10*time.Second, "request timeout")
+	uuid := fs.String("uuid", "", "event ID")
+	header := fs.String("header", "", "event header")
+	description := fs.String("description", "", "event description")
+	owner := fs.String("owner", "", "event owner")
+	date := fs.String("date", "", "event date or start of the period, RFC3339, now if empty")
+	duration := fs.Duration("duration", time.Hour, "event duration")
+	mailing := fs.Int("mailing", 0, "remind this many minutes before the event")
+	urgent := fs.Bool("urgent", false, "urgent event")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), clientUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	dt := time.Now()
+	if *date != "" {
+		var err error
+		dt, err = time.Parse(time.RFC3339, *date)
+		if err != nil {
+			return fmt.Errorf("invalid date %v", err)
+		}
+	}
+	start, _ := ptypes.TimestampProto(dt)
+	stop, _ := ptypes.TimestampProto(dt.Add(*duration))
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := pb.NewAPIClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	event := &pb.Event{
+		UUID:            *uuid,
+		Header:          *header,
+		DateTime:        start,
+		Description:     *description,
+		Owner:           *owner,
+		MailingDuration: int32(*mailing),
+		EventDuration: &pb.EventDuration{
+			Start: start,
+			Stop:  stop,
+		},
+		Urgent: *urgent,
+	}
+
+	var result interface{}
+	switch action := fs.Arg(0); action {
+	case "insert":
+		result, err = client.InsertEvent(ctx, event)
+	case "update":
+		result, err = client.UpdateEvent(ctx, &pb.ChangeEventRequest{Event: event, Id: *uuid})
+	case "remove":
+		result, err = client.RemoveEvent(ctx, &pb.ChangeEventRequest{Id: *uuid})
+	case "day":
+		result, err = client.GetDailyEvents(ctx, &pb.GetRequest{DateTime: start})
+	case "week":
+		result, err = client.GetWeeklyEvents(ctx, &pb.GetRequest{DateTime: start})
+	case "month":
+		result, err = client.GetMonthlyEvents(ctx, &pb.GetRequest{DateTime: start})
+	default:
+		return fmt.Errorf("unknown client action %v", action)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+	return nil
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"calendar/internal/app"
+	cfg "calendar/internal/config"
+	"calendar/internal/lifecycle"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	//база часовых поясов внутри бинарника: конфиг и настройки владельцев проверяются через time.LoadLocation,
+	//а в образе alpine системной базы нет
+	_ "time/tzdata"
+)
+
+const usage = `usage: calendar [shared flags] <command> [flags]
+
+commands:
+  api        gRPC API
+  scheduler  reminder scheduler and outbox relay
+  notifier   reminder notifications and webhook deliveries
+  all        api, scheduler and notifier in one process with in-memory queues
+  migrate    apply database schema
+  client     call the API
+  parking    list or requeue messages that ran out of retries
+  config     print the effective config with secrets redacted, or scan logs for secrets
+
+shared flags (also accepted after the command):
+`
+
+//sharedFlags - флаги, общие для всех команд. Уровень и выводы логов передаются через переменные окружения,
+//поэтому перекрывают конфиг и при его перечитывании
+type sharedFlags struct {
+	config    string
+	logLevel  string
+	logOutput string
+}
+
+func (s *sharedFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&s.config, "config", s.config, "path to config file")
+	fs.StringVar(&s.logLevel, "log-level", s.logLevel, "override logger.level: DEBUG, INFO or ERROR")
+	fs.StringVar(&s.logOutput, "log-output", s.logOutput, "override logger.outputs, comma separated")
+}
+
+//load применяет флаги логирования и читает конфиг
+func (s *sharedFlags) load() (*cfg.Config, error) {
+	if s.logLevel != "" {
+		os.Setenv(cfg.EnvPrefix+"_LOGGER_LEVEL", s.logLevel)
+	}
+	if s.logOutput != "" {
+		os.Setenv(cfg.EnvPrefix+"_LOGGER_OUTPUTS", s.logOutput)
+	}
+	return cfg.Load(s.config)
+}
+
+//commands - команды и их запуск, описание в usage
+var commands = map[string]func(shared *sharedFlags, args []string) error{
+	"api":       runDaemon("calendar-api", startAPI),
+	"scheduler": runDaemon("calendar-scheduler", startScheduler),
+	"notifier":  runDaemon("calendar-notificator", startNotifier),
+	"all":       runDaemon("calendar", (*app.App).StartAll),
+	"migrate":   runMigrate,
+	"client":    runClient,
+	"parking":   runParking,
+	"config":    runConfig,
+}
+
+func main() {
+	shared := &sharedFlags{config: cfg.EnvPath()}
+	global := flag.NewFlagSet("calendar", flag.ExitOnError)
+	shared.register(global)
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage)
+		global.PrintDefaults()
+	}
+	global.Parse(os.Args[1:])
+
+	if global.NArg() == 0 {
+		global.Usage()
+		os.Exit(2)
+	}
+	name := global.Arg(0)
+	run, ok := commands[name]
+	if !ok {
+		names := make([]string, 0, len(commands))
+		for n := range commands {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "unknown command %q, expected one of: %v\n", name, strings.Join(names, ", "))
+		os.Exit(2)
+	}
+
+	err := run(shared, global.Args()[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+//runDaemon разбирает флаги команды, готовит App, запускает в нем компоненты и ждет остановки
+func runDaemon(service string, start func(a *app.App) error) func(shared *sharedFlags, args []string) error {
+	return func(shared *sharedFlags, args []string) error {
+		fs := flag.NewFlagSet(service, flag.ExitOnError)
+		shared.register(fs)
+		fs.Parse(args)
+
+		config, err := shared.load()
+		if err != nil {
+			return err
+		}
+		a, err := app.New(shared.config, config, service)
+		if err != nil {
+			return err
+		}
+
+		err = start(a)
+		if err != nil {
+			a.Logger.Error(err.Error())
+			//то, что уже запущено, останавливается штатно
+			a.Group.Shutdown()
+			a.Group.Wait(config.Shutdown.Timeout)
+			return err
+		}
+		return a.Wait()
+	}
+}
+
+func startAPI(a *app.App) error {
+	psql, err := a.Postgres()
+	if err != nil {
+		return err
+	}
+	return a.StartAPI(psql)
+}
+
+func startScheduler(a *app.App) error {
+	psql, err := a.Postgres()
+	if err != nil {
+		return err
+	}
+	rabbit, err := a.RabbitMQ("rabbitmq", a.Config.RabbitMQ)
+	if err != nil {
+		return err
+	}
+	//брокер закрывается после остановки циклов, которые в него публикуют
+	a.Group.Close("rabbitmq", lifecycle.Func(rabbit.Close))
+	return a.StartScheduler(psql, rabbit)
+}
+
+func startNotifier(a *app.App) error {
+	psql, err := a.Postgres()
+	if err != nil {
+		return err
+	}
+	rabbit, err := a.RabbitMQ("rabbitmq", a.Config.RabbitMQ)
+	if err != nil {
+		return err
+	}
+
+	//вебхуки читают свою очередь, привязанную ко всем типам событий
+	webhookConfig := a.Config.RabbitMQ
+	webhookConfig.Queue = a.Config.Webhooks.Queue
+	webhookConfig.Bindings = a.Config.Webhooks.Bindings
+	webhookRabbit, err := a.RabbitMQ("rabbitmq-webhooks", webhookConfig)
+	if err != nil {
+		rabbit.Close()
+		return err
+	}
+	return a.StartNotifier(psql, rabbit, webhookRabbit)
+}
+
+//runMigrate применяет файлы схемы из каталога -dir
+func runMigrate(shared *sharedFlags, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	shared.register(fs)
+	dir := fs.String("dir", "./docker", "directory with schema files")
+	baseline := fs.Int("baseline", 0, "mark schema files with number up to this as applied without running them")
+	fs.Parse(args)
+
+	config, err := shared.load()
+	if err != nil {
+		return err
+	}
+
+	a := app.NewTool(config)
+	psql, err := a.Postgres()
+	if err != nil {
+		return err
+	}
+	defer psql.Close()
+
+	applied, err := psql.Migrate(a.Group.Context(), *dir, *baseline)
+	for _, name := range applied {
+		a.Logger.Info(fmt.Sprintf("Applied %v", name))
+	}
+	if err != nil {
+		return err
+	}
+	a.Logger.Info(fmt.Sprintf("Schema is up to date, applied %v files", len(applied)))
+	return nil
+}
+
+//runParking показывает сообщения с парковки или возвращает их в очередь
+func runParking(shared *sharedFlags, args []string) error {
+	fs := flag.NewFlagSet("parking", flag.ExitOnError)
+	shared.register(fs)
+	queue := fs.String("queue", "", "queue whose parked messages to use, rabbitmq.queue by default")
+	requeue := fs.Bool("requeue", false, "move parked messages back to the queue")
+	limit := fs.Int("limit", 100, "maximum number of messages to list or requeue")
+	fs.Parse(args)
+
+	config, err := shared.load()
+	if err != nil {
+		return err
+	}
+
+	a := app.NewTool(config)
+	if *requeue {
+		return a.RequeueParked(os.Stdout, *queue, *limit)
+	}
+	return a.ListParked(os.Stdout, *queue, *limit)
+}
+
+//runConfig печатает итоговый конфиг или с -scan-logs ищет секреты в файлах логов.
+//Ошибки конфига печатаются, и команда завершается с кодом 1
+func runConfig(shared *sharedFlags, args []string) error {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	shared.register(fs)
+	scanLogs := fs.Bool("scan-logs", false, "scan log files from logger.outputs for configured secrets")
+	fs.Parse(args)
+
+	config, err := shared.load()
+	if err != nil {
+		return err
+	}
+
+	if *scanLogs {
+		found, err := app.ScanLogs(os.Stdout, config)
+		if err != nil {
+			return err
+		}
+		if found {
+			return errors.New("secrets found in logs")
+		}
+		return nil
+	}
+
+	dump, err := config.Dump()
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(dump))
+	return nil
+}
@@ -0,0 +1,10 @@
+-- запись, которую не удалось разобрать, помечается и больше не задерживает остальные
+ALTER TABLE public.outbox
+    ADD COLUMN failed_at timestamp without time zone,
+    ADD COLUMN error text COLLATE pg_catalog."default";
+
+DROP INDEX public.outbox_unpublished_idx;
+
+CREATE INDEX outbox_unpublished_idx
+    ON public.outbox USING btree (id)
+    WHERE published_at IS NULL AND failed_at IS NULL;
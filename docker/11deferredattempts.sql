@@ -0,0 +1,11 @@
+-- число попыток доставки отложенного напоминания; после max_attempts оно помечается failed_at и больше не берется
+ALTER TABLE public.deferred_reminders
+    ADD COLUMN attempts integer NOT NULL DEFAULT 0,
+    ADD COLUMN error text COLLATE pg_catalog."default",
+    ADD COLUMN failed_at timestamp without time zone;
+
+DROP INDEX public.deferred_reminders_deliver_at_idx;
+
+CREATE INDEX deferred_reminders_deliver_at_idx
+    ON public.deferred_reminders USING btree (deliver_at)
+    WHERE failed_at IS NULL;
@@ -0,0 +1,3 @@
+-- false, пока напоминание отправляется: такую запись повтор из очереди ждет, а не считает доставленной
+ALTER TABLE public.reminder_dedup
+    ADD COLUMN sent boolean NOT NULL DEFAULT true;
@@ -0,0 +1,3 @@
+-- релей занимает пакет записей до claimed_until и публикует их без открытой транзакции
+ALTER TABLE public.outbox
+    ADD COLUMN claimed_until timestamp without time zone;
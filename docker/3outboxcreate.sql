@@ -0,0 +1,20 @@
+CREATE TABLE public.outbox
+(
+    id bigserial NOT NULL,
+    message_id text COLLATE pg_catalog."default" NOT NULL,
+    type text COLLATE pg_catalog."default" NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp without time zone NOT NULL,
+    published_at timestamp without time zone,
+    CONSTRAINT outbox_pkey PRIMARY KEY (id),
+    CONSTRAINT outbox_message_id_key UNIQUE (message_id)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX outbox_unpublished_idx
+    ON public.outbox USING btree (id)
+    WHERE published_at IS NULL;
+
+ALTER TABLE public.outbox
+    OWNER to "user";
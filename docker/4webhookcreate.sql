@@ -0,0 +1,50 @@
+CREATE TABLE public.webhook_subscriptions
+(
+    id serial NOT NULL,
+    uuid text COLLATE pg_catalog."default" NOT NULL,
+    owner text COLLATE pg_catalog."default",
+    url text COLLATE pg_catalog."default" NOT NULL,
+    secret text COLLATE pg_catalog."default" NOT NULL,
+    event_types text[] NOT NULL DEFAULT '{}',
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT webhook_subscriptions_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_subscriptions_uuid_key UNIQUE (uuid)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE public.webhook_subscriptions
+    OWNER to "user";
+
+CREATE TABLE public.webhook_deliveries
+(
+    id bigserial NOT NULL,
+    subscription_uuid text COLLATE pg_catalog."default" NOT NULL,
+    message_id text COLLATE pg_catalog."default" NOT NULL,
+    event_type text COLLATE pg_catalog."default" NOT NULL,
+    payload jsonb NOT NULL,
+    status text COLLATE pg_catalog."default" NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    response_code integer,
+    error text COLLATE pg_catalog."default",
+    created_at timestamp without time zone NOT NULL,
+    next_attempt_at timestamp without time zone NOT NULL,
+    last_attempt_at timestamp without time zone,
+    CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_deliveries_subscription_message_key UNIQUE (subscription_uuid, message_id),
+    CONSTRAINT webhook_deliveries_subscription_fkey FOREIGN KEY (subscription_uuid)
+        REFERENCES public.webhook_subscriptions (uuid) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX webhook_deliveries_pending_idx
+    ON public.webhook_deliveries USING btree (next_attempt_at)
+    WHERE status = 'pending';
+
+CREATE INDEX webhook_deliveries_subscription_idx
+    ON public.webhook_deliveries USING btree (subscription_uuid, created_at DESC);
+
+ALTER TABLE public.webhook_deliveries
+    OWNER to "user";
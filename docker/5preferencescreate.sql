@@ -0,0 +1,55 @@
+ALTER TABLE public.events
+    ADD COLUMN urgent boolean NOT NULL DEFAULT false;
+
+CREATE TABLE public.notification_preferences
+(
+    owner text COLLATE pg_catalog."default" NOT NULL,
+    channels text[] NOT NULL DEFAULT '{}',
+    email text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    webhook_url text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    locale text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    timezone text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    reminder_offsets integer[] NOT NULL DEFAULT '{}',
+    quiet_hours_start text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    quiet_hours_end text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    daily_cap integer NOT NULL DEFAULT 0,
+    updated_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT notification_preferences_pkey PRIMARY KEY (owner)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE public.notification_preferences
+    OWNER to "user";
+
+CREATE TABLE public.notification_counters
+(
+    owner text COLLATE pg_catalog."default" NOT NULL,
+    day date NOT NULL,
+    sent integer NOT NULL DEFAULT 0,
+    CONSTRAINT notification_counters_pkey PRIMARY KEY (owner, day)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE public.notification_counters
+    OWNER to "user";
+
+CREATE TABLE public.deferred_reminders
+(
+    id bigserial NOT NULL,
+    owner text COLLATE pg_catalog."default" NOT NULL,
+    event jsonb NOT NULL,
+    reason text COLLATE pg_catalog."default" NOT NULL,
+    deliver_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT deferred_reminders_pkey PRIMARY KEY (id)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX deferred_reminders_deliver_at_idx
+    ON public.deferred_reminders USING btree (deliver_at);
+
+ALTER TABLE public.deferred_reminders
+    OWNER to "user";
@@ -0,0 +1,20 @@
+CREATE TABLE public.reminder_deliveries
+(
+    id bigserial NOT NULL,
+    event_uuid text COLLATE pg_catalog."default" NOT NULL,
+    owner text COLLATE pg_catalog."default" NOT NULL,
+    channel text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    status text COLLATE pg_catalog."default" NOT NULL,
+    error text COLLATE pg_catalog."default",
+    attempted_at timestamp without time zone NOT NULL,
+    deferred_to timestamp without time zone,
+    CONSTRAINT reminder_deliveries_pkey PRIMARY KEY (id)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX reminder_deliveries_event_idx
+    ON public.reminder_deliveries USING btree (event_uuid, attempted_at DESC);
+
+ALTER TABLE public.reminder_deliveries
+    OWNER to "user";
@@ -0,0 +1,20 @@
+CREATE TABLE public.reminder_dedup
+(
+    reminder_id text COLLATE pg_catalog."default" NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    CONSTRAINT reminder_dedup_pkey PRIMARY KEY (reminder_id)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX reminder_dedup_expires_at_idx
+    ON public.reminder_dedup USING btree (expires_at);
+
+ALTER TABLE public.reminder_dedup
+    OWNER to "user";
+
+ALTER TABLE public.reminder_deliveries
+    ADD COLUMN reminder_id text COLLATE pg_catalog."default" NOT NULL DEFAULT '';
+
+ALTER TABLE public.deferred_reminders
+    ADD COLUMN reminder jsonb;
@@ -0,0 +1,2 @@
+ALTER TABLE public.events
+    ADD COLUMN trace_context text COLLATE pg_catalog."default";
@@ -0,0 +1,17 @@
+-- дубликаты могли появиться, пока проверка uuid и вставка шли отдельными запросами.
+-- Какое из событий оставить, решает человек, поэтому миграция останавливается и перечисляет их
+DO $$
+DECLARE
+    duplicates text;
+BEGIN
+    SELECT string_agg(uuid, ', ' ORDER BY uuid) INTO duplicates
+    FROM (SELECT uuid FROM public.events GROUP BY uuid HAVING count(*) > 1) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'events have duplicate uuids, remove extra rows and run migrate again: %', duplicates;
+    END IF;
+END
+$$;
+
+ALTER TABLE public.events
+    ADD CONSTRAINT events_uuid_key UNIQUE (uuid);
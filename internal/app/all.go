@@ -0,0 +1,33 @@
+package app
+
+import (
+	"calendar/internal/interfaces/memqueue"
+)
+
+//memQueueSize - сколько сообщений очередь в памяти держит до того, как публикация начнет ждать читателя
+const memQueueSize = 1000
+
+//StartAll запускает api, scheduler и notifier в одном процессе с очередями в памяти вместо брокера.
+//Повторов и парковки нет, при остановке непрочитанные сообщения теряются
+func (a *App) StartAll() error {
+	psql, err := a.Postgres()
+	if err != nil {
+		return err
+	}
+
+	exchange := memqueue.NewExchange()
+	reminders := memqueue.NewQueue(a.Logger, memQueueSize)
+	exchange.Bind(reminders, a.Config.RabbitMQ.Bindings...)
+	events := memqueue.NewQueue(a.Logger, memQueueSize)
+	exchange.Bind(events, a.Config.Webhooks.Bindings...)
+
+	err = a.StartAPI(psql)
+	if err != nil {
+		return err
+	}
+	err = a.StartScheduler(psql, exchange)
+	if err != nil {
+		return err
+	}
+	return a.StartNotifier(psql, reminders, events)
+}
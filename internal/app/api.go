@@ -0,0 +1,81 @@
+package app
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/health"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/lifecycle"
+	"calendar/internal/metrics"
+	pb "calendar/internal/proto"
+	"calendar/internal/services"
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"time"
+)
+
+//StartAPI запускает gRPC сервер на grpc.listen вместе с протоколом gRPC health
+func (a *App) StartAPI(psql postgres.PSQL) error {
+	//обьявляем TCP листенер на адресе из grpc.listen
+	netListener, err := net.Listen("tcp", a.Config.GRPC.Listen)
+	if err != nil {
+		return err
+	}
+
+	//создаем grpc сервер и регистрируем его через функцию в прото файлике.
+	//Сроки последними, чтобы метрики видели коды истекших запросов
+	deadlines := &services.Deadlines{}
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(), metrics.UnaryServerInterceptor, deadlines.UnaryServerInterceptor))
+	pb.RegisterAPIServer(grpcServer, services.NewAPI(a.Logger, psql))
+
+	//имена методов известны только после регистрации
+	deadlines.SetMethods(grpcServer.GetServiceInfo())
+	err = deadlines.Set(a.Config.GRPC.Timeout, a.Config.GRPC.Timeouts)
+	if err != nil {
+		return err
+	}
+	a.Watcher.OnChange(func(config *config.Config) error {
+		return deadlines.Set(config.GRPC.Timeout, config.GRPC.Timeouts)
+	}, "grpc.timeout", "grpc.timeouts")
+
+	//сервер готов, когда проходят все проверки готовности процесса
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthUpdater := health.GRPCUpdater{
+		Checker:  a.Checker,
+		Server:   healthServer,
+		Services: []string{"calendar.API"},
+		Interval: 5 * time.Second,
+		Logger:   a.Logger,
+	}
+	a.Group.Go("health updater", healthUpdater.Run)
+
+	//сначала отдаем NOT_SERVING, чтобы балансировщик перестал слать запросы, затем ждем начатые.
+	//Если они не уложились в таймаут, соединения рвутся
+	a.Group.Stop("grpc health", lifecycle.Func(func() error {
+		healthServer.Shutdown()
+		return nil
+	}))
+	a.Group.Stop("grpc server", func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			grpcServer.Stop()
+			return ctx.Err()
+		}
+	})
+
+	a.Group.Go("grpc server", func(ctx context.Context) error {
+		return grpcServer.Serve(netListener)
+	})
+	return nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/health"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/interfaces/rabbitmq"
+	"calendar/internal/lifecycle"
+	lg "calendar/internal/logger"
+	"calendar/internal/metrics"
+	"calendar/internal/tracing"
+	"go.uber.org/zap"
+)
+
+//App - общая часть всех режимов запуска, компоненты добавляются методами Start*
+type App struct {
+	Config  *config.Config
+	Logger  *zap.Logger
+	Group   *lifecycle.Group
+	Checker *health.Checker
+	Watcher *config.Watcher
+}
+
+//New готовит общую часть. service - имя сервиса в трассах
+func New(configPath string, conf *config.Config, service string) (*App, error) {
+	logger, level := lg.GetLogger(conf.Logger)
+	logger.Info("Service loading!")
+
+	a := &App{
+		Config:  conf,
+		Logger:  logger,
+		Group:   lifecycle.New(logger),
+		Checker: health.NewChecker(),
+		Watcher: config.NewWatcher(logger, configPath, conf),
+	}
+
+	metricsServer := metrics.NewServer(logger, conf.Metrics.Listen)
+	metricsServer.Handle("/healthz", a.Checker.LiveHandler())
+	metricsServer.Handle("/readyz", a.Checker.ReadyHandler())
+	err := metricsServer.Run()
+	if err != nil {
+		return nil, err
+	}
+	a.Group.Close("metrics server", metricsServer.Shutdown)
+
+	shutdownTracing, err := tracing.Init(conf.Tracing, service)
+	if err != nil {
+		return nil, err
+	}
+	a.Group.Close("tracing", shutdownTracing)
+
+	//остальные ключи, которые можно менять на лету, добавляют сами компоненты
+	a.Watcher.OnChange(func(config *config.Config) error {
+		level.SetLevel(lg.ParseLevel(config.Logger.Level))
+		return nil
+	}, "logger.level")
+
+	return a, nil
+}
+
+//Postgres подключается к базе, дожидаясь ее при запуске. База закрывается последней, после остановки всех компонентов
+func (a *App) Postgres() (postgres.PSQL, error) {
+	var psql postgres.PSQL
+	var err error
+	err = health.Retry(a.Group.Context(), a.Logger, "postgres", a.Config.Startup, func() error {
+		psql, err = postgres.NewPSQL(a.Logger, a.Config.DB)
+		return err
+	})
+	if err != nil {
+		return postgres.PSQL{}, err
+	}
+	a.Group.Close("postgres", lifecycle.Func(psql.Close))
+	a.Checker.AddReadiness("postgres", psql.Ping)
+	return psql, nil
+}
+
+//RabbitMQ подключается к брокеру, дожидаясь его при запуске. Когда закрывать соединение, решает вызывающий:
+//потребителя - в начале остановки, издателя - после остановки тех, кто в него пишет
+func (a *App) RabbitMQ(name string, config config.RabbitMQConfig) (*rabbitmq.RabbitMQ, error) {
+	var rabbit *rabbitmq.RabbitMQ
+	var err error
+	err = health.Retry(a.Group.Context(), a.Logger, name, a.Config.Startup, func() error {
+		rabbit, err = rabbitmq.NewRabbitMQ(a.Logger, config)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.Checker.AddReadiness(name, rabbit.Ready)
+	return rabbit, nil
+}
+
+//Wait начинает следить за конфигом и блокируется до конца остановки
+func (a *App) Wait() error {
+	err := a.Watcher.Run(a.Group.Context())
+	if err != nil {
+		a.Logger.Error(err.Error())
+	}
+
+	a.Logger.Info("Service started!")
+	return a.Group.Wait(a.Config.Shutdown.Timeout)
+}
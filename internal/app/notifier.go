@@ -0,0 +1,87 @@
+package app
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/lifecycle"
+	"calendar/internal/notification"
+	"calendar/internal/services"
+	"calendar/internal/webhooks"
+	"context"
+	"net/http"
+)
+
+//StartNotifier запускает рассылку напоминаний из reminders и создание доставок вебхуков из events.
+//Обе подписки закрываются в начале остановки: новые сообщения больше не берутся, начатые дообрабатываются
+func (a *App) StartNotifier(psql postgres.PSQL, reminders queue.Subscriber, events queue.Subscriber) error {
+	//настройки владельцев из базы важнее настроек из конфига, там же счетчики, отложенные напоминания,
+	//журнал доставок и отправленные напоминания
+	router, err := notification.NewReloadableRouter(a.Logger, a.Config.Notification, notification.Stores{
+		Preferences: notification.PreferenceFunc(psql.GetNotificationPreferences),
+		Schedule:    &psql,
+		Deliveries:  &psql,
+		Dedup:       &psql,
+	})
+	if err != nil {
+		return err
+	}
+
+	notificator := services.Notificator{
+		Logger:     a.Logger,
+		Subscriber: reminders,
+		Router:     router,
+	}
+
+	dispatcher := &webhooks.Dispatcher{
+		PSQL:       psql,
+		Logger:     a.Logger,
+		Client:     &http.Client{Timeout: a.Config.Webhooks.Timeout},
+		Interval:   a.Config.Webhooks.Interval,
+		BatchSize:  a.Config.Webhooks.BatchSize,
+		RetryDelay: a.Config.Webhooks.RetryDelay,
+		MaxDelay:   a.Config.Webhooks.MaxDelay,
+		MaxAge:     a.Config.Webhooks.MaxAge,
+	}
+
+	deferred := &services.DeferredReminders{
+		PSQL:        psql,
+		Router:      router,
+		Logger:      a.Logger,
+		Interval:    a.Config.Notification.Deferred.Interval,
+		BatchSize:   a.Config.Notification.Deferred.BatchSize,
+		RetryDelay:  a.Config.Notification.Deferred.RetryDelay,
+		MaxAttempts: a.Config.Notification.Deferred.MaxAttempts,
+	}
+
+	a.Group.Go("webhook dispatcher", dispatcher.Run)
+	a.Group.Go("deferred reminders", deferred.Run)
+
+	if a.Config.Notification.Dedup.Backend == notification.DedupPostgres {
+		dedupCleanup := &services.DedupCleanup{
+			PSQL:     psql,
+			Logger:   a.Logger,
+			Interval: a.Config.Notification.Dedup.CleanupInterval,
+		}
+		a.Group.Go("dedup cleanup", dedupCleanup.Run)
+	}
+
+	//на лету применяются настройки каналов: роутер пересоздается целиком.
+	//Очереди, интервалы и хранилище отправленных напоминаний требуют перезапуска
+	a.Watcher.OnChange(func(config *config.Config) error {
+		return router.Reload(config.Notification)
+	}, "notification.channels", "notification.default_channels", "notification.locale", "notification.timezone",
+		"notification.locales", "notification.quiet_hours", "notification.daily_cap", "notification.escalation_channels",
+		"notification.concurrency", "notification.dedup.ttl", "notification.dedup.lease", "notification.console", "notification.smtp",
+		"notification.webhook", "notification.owners")
+
+	a.Group.Go("webhook consumer", func(ctx context.Context) error {
+		return events.Receive(dispatcher.Enqueue)
+	})
+	a.Group.Go("notificator", func(ctx context.Context) error {
+		return notificator.Run()
+	})
+	a.Group.Stop("webhook consumer", lifecycle.Func(events.Close))
+	a.Group.Stop("reminder consumer", lifecycle.Func(reminders.Close))
+	return nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"calendar/internal/interfaces/rabbitmq"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+//ListParked печатает в out до limit сообщений с парковки очереди queue, пустая - rabbitmq.queue
+func (a *App) ListParked(out io.Writer, queue string, limit int) error {
+	rabbit, err := a.parkingRabbitMQ(queue)
+	if err != nil {
+		return err
+	}
+	defer rabbit.Close()
+
+	parked, err := rabbit.ListParked(limit)
+	if err != nil {
+		return err
+	}
+	for _, m := range parked {
+		fmt.Fprintf(out, "id: %v time: %v retries: %v error: %v\n%v\n\n", m.MessageId, m.Timestamp, m.RetryCount, m.LastError, parkedBody(m))
+	}
+	fmt.Fprintf(out, "Total: %v\n", len(parked))
+	return nil
+}
+
+//RequeueParked возвращает до limit сообщений с парковки в очередь queue
+func (a *App) RequeueParked(out io.Writer, queue string, limit int) error {
+	rabbit, err := a.parkingRabbitMQ(queue)
+	if err != nil {
+		return err
+	}
+	defer rabbit.Close()
+
+	moved, err := rabbit.RequeueParked(limit)
+	fmt.Fprintf(out, "Requeued %v messages\n", moved)
+	return err
+}
+
+//parkingRabbitMQ подключается к очереди queue с ее привязками, чтобы топология совпадала с сервисом
+func (a *App) parkingRabbitMQ(queue string) (*rabbitmq.RabbitMQ, error) {
+	rabbitConfig := a.Config.RabbitMQ
+	if queue != "" {
+		rabbitConfig.Queue = queue
+	}
+	if rabbitConfig.Queue == a.Config.Webhooks.Queue {
+		rabbitConfig.Bindings = a.Config.Webhooks.Bindings
+	}
+	return a.RabbitMQ("rabbitmq", rabbitConfig)
+}
+
+//parkedBody печатает конверт как JSON независимо от формата сообщения
+func parkedBody(m rabbitmq.ParkedMessage) string {
+	envelope, err := m.Envelope()
+	if err != nil {
+		return fmt.Sprintf("undecodable %v body (%v): %q", m.ContentType, err, m.Body)
+	}
+	b, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%q", m.Body)
+	}
+	return string(b)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/services"
+)
+
+//StartScheduler запускает поиск наступивших напоминаний и публикацию outbox в publisher.
+//Закрыть publisher нужно после остановки, когда оба цикла завершатся
+func (a *App) StartScheduler(psql postgres.PSQL, publisher queue.Publisher) error {
+	bgProcessor := &services.BackgroundProcessor{
+		Logger:    a.Logger,
+		Publisher: publisher,
+		PSQL:      psql,
+		Interval:  a.Config.Scheduler.Interval,
+	}
+	bgProcessor.SetOwnerOffsets(a.Config.Notification.Owners)
+
+	outboxRelay := &services.OutboxRelay{
+		Logger:         a.Logger,
+		Publisher:      publisher,
+		PSQL:           psql,
+		Interval:       a.Config.Outbox.Interval,
+		BatchSize:      a.Config.Outbox.BatchSize,
+		Retention:      a.Config.Outbox.Retention,
+		PublishTimeout: a.Config.RabbitMQ.ConfirmTimeout,
+	}
+
+	//интервал проверки меняется на лету
+	a.Watcher.OnChange(func(config *config.Config) error {
+		bgProcessor.SetInterval(config.Scheduler.Interval)
+		return nil
+	}, "scheduler.interval")
+	a.Watcher.OnChange(func(config *config.Config) error {
+		bgProcessor.SetOwnerOffsets(config.Notification.Owners)
+		return nil
+	}, "notification.owners")
+
+	//зависший цикл напоминаний лечится перезапуском
+	a.Checker.AddLiveness("scheduler", bgProcessor.Alive)
+
+	a.Group.Go("scheduler", bgProcessor.Run)
+	a.Group.Go("outbox relay", outboxRelay.Run)
+	return nil
+}
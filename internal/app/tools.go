@@ -0,0 +1,72 @@
+package app
+
+import (
+	"bufio"
+	"calendar/internal/config"
+	"calendar/internal/health"
+	"calendar/internal/lifecycle"
+	lg "calendar/internal/logger"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+//NewTool готовит App для разовых команд: без сервера метрик, трассировки и слежения за конфигом
+func NewTool(conf *config.Config) *App {
+	logger, _ := lg.GetLogger(conf.Logger)
+	return &App{
+		Config:  conf,
+		Logger:  logger,
+		Group:   lifecycle.New(logger),
+		Checker: health.NewChecker(),
+	}
+}
+
+//ScanLogs печатает в out каждую строку логов из logger.outputs, в которой встречается секрет. Сами секреты не печатаются
+func ScanLogs(out io.Writer, conf *config.Config) (bool, error) {
+	secrets := conf.Secrets()
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	found := false
+	for _, output := range conf.Logger.Outputs {
+		if output == "stdout" || output == "stderr" {
+			continue
+		}
+
+		file, err := os.Open(output)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return found, err
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			for _, key := range keys {
+				if strings.Contains(scanner.Text(), secrets[key].Value()) {
+					fmt.Fprintf(out, "%v:%v: contains %v\n", output, line, key)
+					found = true
+				}
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return found, err
+		}
+	}
+
+	if !found {
+		fmt.Fprintf(out, "No secrets found in logs (%v checked)\n", len(keys))
+	}
+	return found, nil
+}
@@ -1,31 +1,339 @@
 package config
 
 import (
+	"fmt"
 	"github.com/spf13/viper"
-	"log"
+	"os"
+	"reflect"
+	"strings"
+	"time"
 )
 
-func GetConfig() map[string]interface{} {
-	m := make(map[string]interface{})
-	viper.SetConfigName("config")    // name of config file (without extension)
-	viper.AddConfigPath("./configs") // path to look for the config file in
-	viper.SetConfigType("yaml")
-	err := viper.ReadInConfig() // Find and read the config file
-	if err != nil {             // Handle errors reading the config file
-		log.Fatalf("Fatal error config file: %s \n", err)
-	}
-	m["outputs"] = viper.GetStringSlice("logger.outputs")
-	m["logLevel"] = viper.GetString("logger.level")
-	m["user"] = viper.GetString("db.user")
-	m["password"] = viper.GetString("db.password")
-	m["sslmode"] = viper.GetString("db.sslmode")
-	m["host"] = viper.GetString("db.host")
-	m["dbname"] = viper.GetString("db.dbname")
-	m["rabbitmq.user"] = viper.GetString("rabbitmq.user")
-	m["rabbitmq.password"] = viper.GetString("rabbitmq.password")
-	m["rabbitmq.host"] = viper.GetString("rabbitmq.host")
-	m["rabbitmq.vhost"] = viper.GetString("rabbitmq.vhost")
-	m["rabbitmq.port"] = viper.GetString("rabbitmq.port")
-
-	return m
+//DefaultPath - файл конфигурации, если не задан флаг --config или CALENDAR_CONFIG
+const DefaultPath = "./configs/config.yaml"
+
+//rabbitmq.confirm_timeout -> CALENDAR_RABBITMQ_CONFIRM_TIMEOUT
+const EnvPrefix = "CALENDAR"
+
+//keyDelimiter заменяет точку в путях viper, потому что ключи notification.owners - адреса почты
+const keyDelimiter = "::"
+
+type Config struct {
+	Logger       LoggerConfig       `mapstructure:"logger"`
+	DB           DBConfig           `mapstructure:"db"`
+	RabbitMQ     RabbitMQConfig     `mapstructure:"rabbitmq"`
+	GRPC         GRPCConfig         `mapstructure:"grpc"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
+	Startup      StartupConfig      `mapstructure:"startup"`
+	Shutdown     ShutdownConfig     `mapstructure:"shutdown"`
+	Scheduler    SchedulerConfig    `mapstructure:"scheduler"`
+	Outbox       OutboxConfig       `mapstructure:"outbox"`
+	Notification NotificationConfig `mapstructure:"notification"`
+	Webhooks     WebhooksConfig     `mapstructure:"webhooks"`
+}
+
+type LoggerConfig struct {
+	Level   string   `mapstructure:"level"`
+	Outputs []string `mapstructure:"outputs"`
+}
+
+type DBConfig struct {
+	User     string `mapstructure:"user"`
+	Password Secret `mapstructure:"password"`
+	SSLMode  string `mapstructure:"sslmode"`
+	Host     string `mapstructure:"host"`
+	DBName   string `mapstructure:"dbname"`
+}
+
+type RabbitMQConfig struct {
+	User              string        `mapstructure:"user"`
+	Password          Secret        `mapstructure:"password"`
+	Host              string        `mapstructure:"host"`
+	Port              int           `mapstructure:"port"`
+	VHost             string        `mapstructure:"vhost"`
+	Exchange          string        `mapstructure:"exchange"`
+	Queue             string        `mapstructure:"queue"`
+	Bindings          []string      `mapstructure:"bindings"`
+	ContentType       string        `mapstructure:"content_type"`
+	Durable           bool          `mapstructure:"durable"`
+	ConfirmTimeout    time.Duration `mapstructure:"confirm_timeout"`
+	MaxRetries        int           `mapstructure:"max_retries"`
+	RetryDelay        time.Duration `mapstructure:"retry_delay"`
+	ReconnectDelay    time.Duration `mapstructure:"reconnect_delay"`
+	ReconnectMaxDelay time.Duration `mapstructure:"reconnect_max_delay"`
+	OutageMode        string        `mapstructure:"outage_mode"`
+	OutageBuffer      int           `mapstructure:"outage_buffer"`
+	Prefetch          int           `mapstructure:"prefetch"`
+	Workers           int           `mapstructure:"workers"`
+	WorkerQueue       int           `mapstructure:"worker_queue"`
+	ShutdownTimeout   time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+//Timeouts - сроки отдельных методов по имени из API.proto (getMonthlyEvents)
+type GRPCConfig struct {
+	Listen   string                   `mapstructure:"listen"`
+	Timeout  time.Duration            `mapstructure:"timeout"`
+	Timeouts map[string]time.Duration `mapstructure:"timeouts"`
+}
+
+//MetricsConfig - HTTP сервер с /metrics, пустой адрес отключает его
+type MetricsConfig struct {
+	Listen string `mapstructure:"listen"`
+}
+
+//StartupConfig - ожидание базы и брокера при запуске
+type StartupConfig struct {
+	Timeout    time.Duration `mapstructure:"timeout"`
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+	MaxDelay   time.Duration `mapstructure:"max_delay"`
+}
+
+//ShutdownConfig - общий таймаут остановки по SIGTERM, включая дообработку сообщений из очереди
+type ShutdownConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type SchedulerConfig struct {
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type OutboxConfig struct {
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+type NotificationConfig struct {
+	Channels           []string               `mapstructure:"channels"`
+	DefaultChannels    []string               `mapstructure:"default_channels"`
+	Locale             string                 `mapstructure:"locale"`
+	TimeZone           string                 `mapstructure:"timezone"`
+	Locales            string                 `mapstructure:"locales"`
+	QuietHours         QuietHoursConfig       `mapstructure:"quiet_hours"`
+	DailyCap           int32                  `mapstructure:"daily_cap"`
+	EscalationChannels []string               `mapstructure:"escalation_channels"`
+	Deferred           DeferredConfig         `mapstructure:"deferred"`
+	Concurrency        map[string]int         `mapstructure:"concurrency"`
+	Dedup              DedupConfig            `mapstructure:"dedup"`
+	Console            ConsoleConfig          `mapstructure:"console"`
+	SMTP               SMTPConfig             `mapstructure:"smtp"`
+	Webhook            WebhookConfig          `mapstructure:"webhook"`
+	Owners             map[string]OwnerConfig `mapstructure:"owners"`
+}
+
+type QuietHoursConfig struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+type DeferredConfig struct {
+	Interval    time.Duration `mapstructure:"interval"`
+	BatchSize   int           `mapstructure:"batch_size"`
+	RetryDelay  time.Duration `mapstructure:"retry_delay"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+}
+
+type DedupConfig struct {
+	Backend         string        `mapstructure:"backend"`
+	TTL             time.Duration `mapstructure:"ttl"`
+	Lease           time.Duration `mapstructure:"lease"`
+	Size            int           `mapstructure:"size"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
+
+type ConsoleConfig struct {
+	Output string `mapstructure:"output"`
+}
+
+type SMTPConfig struct {
+	Host               string        `mapstructure:"host"`
+	Port               string        `mapstructure:"port"`
+	User               string        `mapstructure:"user"`
+	Password           Secret        `mapstructure:"password"`
+	From               string        `mapstructure:"from"`
+	StartTLS           string        `mapstructure:"starttls"`
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify"`
+	Templates          string        `mapstructure:"templates"`
+	Timeout            time.Duration `mapstructure:"timeout"`
+}
+
+type WebhookConfig struct {
+	URL     string        `mapstructure:"url"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+//OwnerConfig - настройки владельца в notification.owners
+type OwnerConfig struct {
+	Channels        []string         `mapstructure:"channels"`
+	Email           string           `mapstructure:"email"`
+	Webhook         string           `mapstructure:"webhook"`
+	Locale          string           `mapstructure:"locale"`
+	TimeZone        string           `mapstructure:"timezone"`
+	ReminderOffsets []int32          `mapstructure:"reminder_offsets"`
+	QuietHours      QuietHoursConfig `mapstructure:"quiet_hours"`
+	DailyCap        int32            `mapstructure:"daily_cap"`
+}
+
+type WebhooksConfig struct {
+	Queue      string        `mapstructure:"queue"`
+	Bindings   []string      `mapstructure:"bindings"`
+	Interval   time.Duration `mapstructure:"interval"`
+	BatchSize  int           `mapstructure:"batch_size"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+	MaxDelay   time.Duration `mapstructure:"max_delay"`
+	MaxAge     time.Duration `mapstructure:"max_age"`
+}
+
+//EnvPath возвращает путь к конфигу из CALENDAR_CONFIG или DefaultPath
+func EnvPath() string {
+	path := os.Getenv(EnvPrefix + "_CONFIG")
+	if path == "" {
+		path = DefaultPath
+	}
+	return path
+}
+
+//неизвестные ключи - ошибка, чтобы опечатка не превращалась в пустое значение
+func Load(path string) (*Config, error) {
+	v := viper.NewWithOptions(viper.KeyDelimiter(keyDelimiter))
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	setDefaults(v)
+
+	err := bindEnv(v, nil, reflect.TypeOf(Config{}))
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.ReadInConfig()
+	if err != nil {
+		return nil, fmt.Errorf("read config %v: %v", path, err)
+	}
+
+	config := &Config{}
+	err = v.UnmarshalExact(config)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %v: %v", path, err)
+	}
+
+	err = loadSecretFiles(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func setDefaults(v *viper.Viper) {
+	set := func(key string, value interface{}) {
+		v.SetDefault(strings.ReplaceAll(key, ".", keyDelimiter), value)
+	}
+	set("logger.level", "INFO")
+	set("logger.outputs", []string{"stderr"})
+	set("db.sslmode", "disable")
+	set("db.host", "localhost")
+	set("db.dbname", "calendar")
+	set("rabbitmq.host", "localhost")
+	set("rabbitmq.port", 5672)
+	set("rabbitmq.exchange", "calendar.events")
+	set("rabbitmq.queue", "calendar")
+	set("rabbitmq.bindings", []string{"reminder.due"})
+	set("rabbitmq.content_type", "application/json")
+	set("rabbitmq.durable", true)
+	set("rabbitmq.confirm_timeout", "5s")
+	set("rabbitmq.max_retries", 5)
+	set("rabbitmq.retry_delay", "1s")
+	set("rabbitmq.reconnect_delay", "1s")
+	set("rabbitmq.reconnect_max_delay", "30s")
+	set("rabbitmq.outage_mode", "fail")
+	set("rabbitmq.outage_buffer", 1000)
+	set("rabbitmq.prefetch", 20)
+	set("rabbitmq.workers", 4)
+	set("rabbitmq.worker_queue", 4)
+	set("rabbitmq.shutdown_timeout", "30s")
+	set("grpc.listen", ":50051")
+	set("grpc.timeout", "10s")
+	set("metrics.listen", ":2112")
+	set("startup.timeout", "2m")
+	set("startup.retry_delay", "1s")
+	set("startup.max_delay", "15s")
+	set("shutdown.timeout", "45s")
+	set("tracing.exporter", "none")
+	set("tracing.endpoint", "localhost:4317")
+	set("tracing.insecure", true)
+	set("tracing.sample_ratio", 1.0)
+	set("scheduler.interval", "10s")
+	set("outbox.interval", "1s")
+	set("outbox.batch_size", 100)
+	set("outbox.retention", "168h")
+	set("notification.channels", []string{"console"})
+	set("notification.default_channels", []string{"console"})
+	set("notification.locale", "ru")
+	set("notification.timezone", "Europe/Moscow")
+	set("notification.locales", "./configs/locales")
+	set("notification.escalation_channels", []string{})
+	set("notification.deferred.interval", "30s")
+	set("notification.deferred.batch_size", 100)
+	set("notification.deferred.retry_delay", "1m")
+	set("notification.deferred.max_attempts", 10)
+	set("notification.dedup.backend", "memory")
+	set("notification.dedup.ttl", "72h")
+	set("notification.dedup.lease", "30s")
+	set("notification.dedup.size", 100000)
+	set("notification.dedup.cleanup_interval", "1h")
+	set("notification.console.output", "stdout")
+	set("notification.smtp.port", "25")
+	set("notification.smtp.starttls", "opportunistic")
+	set("notification.smtp.templates", "./configs/templates/email")
+	set("notification.smtp.timeout", "10s")
+	set("notification.webhook.timeout", "5s")
+	set("webhooks.queue", "calendar.webhooks")
+	set("webhooks.bindings", []string{"#"})
+	set("webhooks.interval", "1s")
+	set("webhooks.batch_size", 50)
+	set("webhooks.timeout", "10s")
+	set("webhooks.retry_delay", "10s")
+	set("webhooks.max_delay", "1h")
+	set("webhooks.max_age", "24h")
+}
+
+//без bindEnv viper не видит переменные для ключей, которых нет в файле. Словари задаются только в файле
+func bindEnv(v *viper.Viper, path []string, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := append(append([]string{}, path...), field.Tag.Get("mapstructure"))
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			err := bindEnv(v, key, field.Type)
+			if err != nil {
+				return err
+			}
+			continue
+		case reflect.Map:
+			continue
+		}
+		err := v.BindEnv(strings.Join(key, keyDelimiter), envName(key))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//envName - имя переменной окружения для ключа: [rabbitmq confirm_timeout] -> CALENDAR_RABBITMQ_CONFIRM_TIMEOUT
+func envName(key []string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.Join(key, "_"))
 }
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+//Dump возвращает итоговый конфиг в JSON с ключами как в файле. Секреты выводятся как Redacted
+func (c *Config) Dump() ([]byte, error) {
+	return json.MarshalIndent(dumpValue(reflect.ValueOf(*c)), "", "  ")
+}
+
+func dumpValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		for i := 0; i < value.NumField(); i++ {
+			m[value.Type().Field(i).Tag.Get("mapstructure")] = dumpValue(value.Field(i))
+		}
+		return m
+	case reflect.Map:
+		m := make(map[string]interface{})
+		iter := value.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = dumpValue(iter.Value())
+		}
+		return m
+	case reflect.Int64:
+		if d, ok := value.Interface().(time.Duration); ok {
+			return d.String()
+		}
+	}
+	return value.Interface()
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+//Redacted печатается вместо значения секрета
+const Redacted = "******"
+
+//Secret выводится как Redacted, настоящее значение возвращает только Value
+type Secret string
+
+var secretType = reflect.TypeOf(Secret(""))
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return Redacted
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+//Secrets возвращает все заданные секреты по ключам конфига, например db.password
+func (c *Config) Secrets() map[string]Secret {
+	secrets := make(map[string]Secret)
+	walkSecrets(reflect.ValueOf(c).Elem(), nil, func(path []string, value reflect.Value) error {
+		if secret := value.Interface().(Secret); secret != "" {
+			secrets[strings.Join(path, ".")] = secret
+		}
+		return nil
+	})
+	return secrets
+}
+
+//CALENDAR_DB_PASSWORD_FILE=/run/secrets/db_password
+func loadSecretFiles(c *Config) error {
+	return walkSecrets(reflect.ValueOf(c).Elem(), nil, func(path []string, value reflect.Value) error {
+		env := envName(path)
+		file := os.Getenv(env + "_FILE")
+		if file == "" {
+			return nil
+		}
+		if _, ok := os.LookupEnv(env); ok {
+			return fmt.Errorf("both %v and %v_FILE are set", env, env)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read %v_FILE: %v", env, err)
+		}
+		value.SetString(strings.TrimRight(string(data), "\r\n"))
+		return nil
+	})
+}
+
+func walkSecrets(value reflect.Value, path []string, fn func(path []string, value reflect.Value) error) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := append(append([]string{}, path...), field.Tag.Get("mapstructure"))
+		switch {
+		case field.Type == secretType:
+			err := fn(key, value.Field(i))
+			if err != nil {
+				return err
+			}
+		case field.Type.Kind() == reflect.Struct:
+			err := walkSecrets(value.Field(i), key, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
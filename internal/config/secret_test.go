@@ -0,0 +1,68 @@
+package config_test
+
+import (
+	"bytes"
+	"calendar/internal/config"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/interfaces/rabbitmq"
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"testing"
+)
+
+//TestSecretsNotLogged подключается к базе и брокеру, пишет конфиг в лог и делает дамп,
+//после чего ищет в выводе значения всех секретов. Подключения отклоняются, поэтому в лог попадают и их ошибки
+func TestSecretsNotLogged(t *testing.T) {
+	//пробел и кавычка в пароле ломают строку подключения без экранирования, и ее разбор печатает часть пароля
+	t.Setenv("CALENDAR_DB_PASSWORD", "db pass'word")
+	t.Setenv("CALENDAR_DB_HOST", "127.0.0.1")
+	t.Setenv("CALENDAR_DB_SSLMODE", "disable")
+	t.Setenv("CALENDAR_RABBITMQ_PASSWORD", "rabbit-password")
+	t.Setenv("CALENDAR_RABBITMQ_HOST", "127.0.0.1")
+	t.Setenv("CALENDAR_RABBITMQ_PORT", "1")
+	t.Setenv("CALENDAR_NOTIFICATION_SMTP_PASSWORD", "smtp-password")
+
+	c, err := config.Load("../../configs/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := c.Secrets()
+	if len(secrets) != 3 {
+		t.Fatalf("expected 3 secrets, got %v", len(secrets))
+	}
+
+	var out bytes.Buffer
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&out), zap.DebugLevel))
+
+	psql, err := postgres.NewPSQL(logger, c.DB)
+	if err != nil {
+		logger.Error(err.Error())
+	} else {
+		psql.Close()
+	}
+	rabbit, err := rabbitmq.NewRabbitMQ(logger, c.RabbitMQ)
+	if err != nil {
+		logger.Error(err.Error())
+	} else {
+		rabbit.Close()
+	}
+
+	logger.Info("Config", zap.Any("config", c))
+	logger.Info(fmt.Sprintf("Config %+v", *c))
+	dump, err := c.Dump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out.Write(dump)
+
+	for key, secret := range secrets {
+		if strings.Contains(out.String(), secret.Value()) {
+			t.Errorf("%v leaked:\n%v", key, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "pass'word") {
+		t.Errorf("part of db.password leaked:\n%v", out.String())
+	}
+}
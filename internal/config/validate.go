@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//ValidationError содержит все найденные ошибки, чтобы их можно было исправить за один раз
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid config:\n  " + strings.Join(e, "\n  ")
+}
+
+//Validate проверяет значения, которые иначе привели бы к ошибке только во время работы
+func (c *Config) Validate() error {
+	var errs ValidationError
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Logger.Level, "DEBUG", "INFO", "ERROR"), "logger.level: unknown level %q", c.Logger.Level)
+	check(len(c.Logger.Outputs) > 0, "logger.outputs: at least one output is required")
+
+	check(c.DB.User != "", "db.user: required")
+	check(c.DB.Host != "", "db.host: required")
+	check(c.DB.DBName != "", "db.dbname: required")
+
+	check(c.RabbitMQ.User != "", "rabbitmq.user: required")
+	check(c.RabbitMQ.Host != "", "rabbitmq.host: required")
+	check(c.RabbitMQ.Port > 0 && c.RabbitMQ.Port < 65536, "rabbitmq.port: invalid port %v", c.RabbitMQ.Port)
+	check(c.RabbitMQ.Exchange != "", "rabbitmq.exchange: required")
+	check(c.RabbitMQ.Queue != "", "rabbitmq.queue: required")
+	check(oneOf(c.RabbitMQ.ContentType, "application/json", "application/x-protobuf"), "rabbitmq.content_type: unsupported content type %q", c.RabbitMQ.ContentType)
+	check(c.RabbitMQ.ConfirmTimeout > 0, "rabbitmq.confirm_timeout: must be positive")
+	check(c.RabbitMQ.MaxRetries >= 0, "rabbitmq.max_retries: must not be negative")
+	check(c.RabbitMQ.RetryDelay > 0, "rabbitmq.retry_delay: must be positive")
+	check(c.RabbitMQ.ReconnectDelay > 0, "rabbitmq.reconnect_delay: must be positive")
+	check(c.RabbitMQ.ReconnectMaxDelay >= c.RabbitMQ.ReconnectDelay, "rabbitmq.reconnect_max_delay: must not be less than reconnect_delay")
+	check(oneOf(c.RabbitMQ.OutageMode, "fail", "buffer"), "rabbitmq.outage_mode: unknown mode %q", c.RabbitMQ.OutageMode)
+	check(c.RabbitMQ.OutageBuffer >= 0, "rabbitmq.outage_buffer: must not be negative")
+	check(c.RabbitMQ.Prefetch >= 0, "rabbitmq.prefetch: must not be negative")
+	check(c.RabbitMQ.Workers > 0, "rabbitmq.workers: must be positive")
+	check(c.RabbitMQ.WorkerQueue >= 0, "rabbitmq.worker_queue: must not be negative")
+	check(c.RabbitMQ.ShutdownTimeout > 0, "rabbitmq.shutdown_timeout: must be positive")
+
+	check(c.GRPC.Listen != "", "grpc.listen: required")
+	check(c.GRPC.Timeout > 0, "grpc.timeout: must be positive")
+	for method, timeout := range c.GRPC.Timeouts {
+		check(timeout > 0, "grpc.timeouts.%v: must be positive", method)
+	}
+	check(c.Startup.Timeout > 0, "startup.timeout: must be positive")
+	check(c.Startup.RetryDelay > 0, "startup.retry_delay: must be positive")
+	check(c.Startup.MaxDelay >= c.Startup.RetryDelay, "startup.max_delay: must not be less than retry_delay")
+	check(c.Shutdown.Timeout >= c.RabbitMQ.ShutdownTimeout, "shutdown.timeout: must not be less than rabbitmq.shutdown_timeout")
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "otlp"), "tracing.exporter: unknown exporter %q", c.Tracing.Exporter)
+	if c.Tracing.Exporter == "otlp" {
+		check(c.Tracing.Endpoint != "", "tracing.endpoint: required for otlp exporter")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+	check(c.Scheduler.Interval > 0, "scheduler.interval: must be positive")
+
+	check(c.Outbox.Interval > 0, "outbox.interval: must be positive")
+	check(c.Outbox.BatchSize > 0, "outbox.batch_size: must be positive")
+	check(c.Outbox.Retention > 0, "outbox.retention: must be positive")
+
+	n := c.Notification
+	channels := []string{"console", "email", "webhook"}
+	for _, channel := range n.Channels {
+		check(oneOf(channel, channels...), "notification.channels: unknown channel %q", channel)
+	}
+	for _, channel := range n.DefaultChannels {
+		check(oneOf(channel, n.Channels...), "notification.default_channels: channel %q is not enabled", channel)
+	}
+	for _, channel := range n.EscalationChannels {
+		check(oneOf(channel, n.Channels...), "notification.escalation_channels: channel %q is not enabled", channel)
+	}
+	for channel, limit := range n.Concurrency {
+		check(oneOf(channel, channels...), "notification.concurrency: unknown channel %q", channel)
+		check(limit > 0, "notification.concurrency.%v: must be positive", channel)
+	}
+	check(n.Locale != "", "notification.locale: required")
+	check(n.Locales != "", "notification.locales: required")
+	checkTimeZone(check, "notification.timezone", n.TimeZone)
+	checkQuietHours(check, "notification.quiet_hours", n.QuietHours)
+	check(n.DailyCap >= 0, "notification.daily_cap: must not be negative")
+	check(n.Deferred.Interval > 0, "notification.deferred.interval: must be positive")
+	check(n.Deferred.BatchSize > 0, "notification.deferred.batch_size: must be positive")
+	check(n.Deferred.RetryDelay > 0, "notification.deferred.retry_delay: must be positive")
+	check(n.Deferred.MaxAttempts > 0, "notification.deferred.max_attempts: must be positive")
+	//без dedup ошибка одного канала возвращает напоминание в очередь, и повтор снова отправит его по каналам, где доставка прошла
+	check(n.Dedup.Backend != "none", "notification.dedup.backend: none is not supported, a retry would resend channels that already succeeded")
+	check(oneOf(n.Dedup.Backend, "none", "memory", "postgres"), "notification.dedup.backend: unknown backend %q", n.Dedup.Backend)
+	check(n.Dedup.TTL > 0, "notification.dedup.ttl: must be positive")
+	check(n.Dedup.Lease > 0 && n.Dedup.Lease <= n.Dedup.TTL, "notification.dedup.lease: must be positive and not exceed ttl")
+	if n.Dedup.Backend == "memory" {
+		check(n.Dedup.Size > 0, "notification.dedup.size: must be positive")
+	}
+	if n.Dedup.Backend == "postgres" {
+		check(n.Dedup.CleanupInterval > 0, "notification.dedup.cleanup_interval: must be positive")
+	}
+	if oneOf("email", n.Channels...) {
+		check(n.SMTP.Host != "", "notification.smtp.host: required for email channel")
+		check(n.SMTP.From != "", "notification.smtp.from: required for email channel")
+		check(n.SMTP.Templates != "", "notification.smtp.templates: required for email channel")
+		check(oneOf(n.SMTP.StartTLS, "none", "opportunistic", "required"), "notification.smtp.starttls: unknown mode %q", n.SMTP.StartTLS)
+	}
+	for owner, prefs := range n.Owners {
+		key := "notification.owners." + owner
+		for _, channel := range prefs.Channels {
+			check(oneOf(channel, n.Channels...), "%v.channels: channel %q is not enabled", key, channel)
+		}
+		checkTimeZone(check, key+".timezone", prefs.TimeZone)
+		checkQuietHours(check, key+".quiet_hours", prefs.QuietHours)
+		for _, offset := range prefs.ReminderOffsets {
+			check(offset >= 0, "%v.reminder_offsets: offset %v must not be negative", key, offset)
+		}
+		check(prefs.DailyCap >= 0, "%v.daily_cap: must not be negative", key)
+	}
+
+	check(c.Webhooks.Queue != "", "webhooks.queue: required")
+	check(c.Webhooks.Interval > 0, "webhooks.interval: must be positive")
+	check(c.Webhooks.BatchSize > 0, "webhooks.batch_size: must be positive")
+	check(c.Webhooks.Timeout > 0, "webhooks.timeout: must be positive")
+	check(c.Webhooks.RetryDelay > 0, "webhooks.retry_delay: must be positive")
+	check(c.Webhooks.MaxDelay >= c.Webhooks.RetryDelay, "webhooks.max_delay: must not be less than retry_delay")
+	check(c.Webhooks.MaxAge > 0, "webhooks.max_age: must be positive")
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+func checkTimeZone(check func(bool, string, ...interface{}), key, name string) {
+	if name == "" {
+		return
+	}
+	_, err := time.LoadLocation(name)
+	check(err == nil, "%v: unknown time zone %q", key, name)
+}
+
+//checkQuietHours проверяет формат ЧЧ:ММ, пустые значения отключают тихие часы
+func checkQuietHours(check func(bool, string, ...interface{}), key string, quiet QuietHoursConfig) {
+	for _, value := range []string{quiet.Start, quiet.End} {
+		if value == "" {
+			continue
+		}
+		_, err := time.Parse("15:04", value)
+		check(err == nil, "%v: invalid time %q, expected HH:MM", key, value)
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+//reloadDelay - сколько ждать после изменения файла, редакторы пишут его в несколько приемов
+const reloadDelay = 200 * time.Millisecond
+
+type reloadHandler struct {
+	keys  []string
+	apply func(config *Config) error
+}
+
+//Watcher перечитывает конфиг при изменении файла и по SIGHUP
+type Watcher struct {
+	path     string
+	logger   *zap.Logger
+	mu       sync.Mutex
+	current  *Config
+	handlers []reloadHandler
+}
+
+func NewWatcher(logger *zap.Logger, path string, current *Config) *Watcher {
+	return &Watcher{
+		path:    path,
+		logger:  logger,
+		current: current,
+	}
+}
+
+//OnChange принимает ключи (logger.level) или секции (notification.smtp)
+func (w *Watcher) OnChange(apply func(config *Config) error, keys ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, reloadHandler{keys: keys, apply: apply})
+}
+
+//Run запускает слежение в фоне. Оно прекращается с отменой ctx
+func (w *Watcher) Run(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	//следим за каталогом, потому что редакторы и Kubernetes заменяют файл, а не пишут в него
+	err = fsWatcher.Add(filepath.Dir(w.path))
+	if err != nil {
+		fsWatcher.Close()
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer fsWatcher.Close()
+		defer signal.Stop(signals)
+
+		var pending <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if w.affects(event) {
+					pending = time.After(reloadDelay)
+				}
+			case err, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				w.logger.Error(fmt.Sprintf("Config watch error %v", err))
+			case <-pending:
+				pending = nil
+				w.Reload()
+			case <-signals:
+				w.logger.Info("Received SIGHUP, reloading config")
+				w.Reload()
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (w *Watcher) affects(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+		return false
+	}
+	return filepath.Clean(event.Name) == filepath.Clean(w.path) || filepath.Base(event.Name) == "..data"
+}
+
+//Reload читает конфиг и применяет изменения
+func (w *Watcher) Reload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	config, err := Load(w.path)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Config reload failed, keeping current config: %v", err))
+		return
+	}
+
+	changed := diff(reflect.ValueOf(*w.current), reflect.ValueOf(*config), nil)
+	if len(changed) == 0 {
+		return
+	}
+
+	//handled[key] = false, если обработчик вернул ошибку, она уже записана в лог
+	handled := make(map[string]bool)
+	for _, handler := range w.handlers {
+		keys := matching(changed, handler.keys)
+		if len(keys) == 0 {
+			continue
+		}
+		err = handler.apply(config)
+		if err != nil {
+			w.logger.Error(fmt.Sprintf("Failed to apply %v: %v", strings.Join(keys, ", "), err))
+		}
+		for _, key := range keys {
+			handled[key] = err == nil
+		}
+	}
+
+	//ключи, которые не удалось применить, остаются со старым значением, чтобы следующее перечитывание применило их снова
+	var live, restart []string
+	for _, key := range changed {
+		applied, ok := handled[key]
+		switch {
+		case !ok:
+			restart = append(restart, key)
+		case applied:
+			live = append(live, key)
+		default:
+			restore(reflect.ValueOf(config).Elem(), reflect.ValueOf(w.current).Elem(), strings.Split(key, "."))
+		}
+	}
+	w.current = config
+	if len(live) > 0 {
+		w.logger.Info(fmt.Sprintf("Config reloaded, applied: %v", strings.Join(live, ", ")))
+	}
+	if len(restart) > 0 {
+		w.logger.Warn(fmt.Sprintf("Config changes require restart: %v", strings.Join(restart, ", ")))
+	}
+}
+
+//matching возвращает измененные ключи, которые совпадают с одним из keys или вложены в него
+func matching(changed []string, keys []string) []string {
+	result := make([]string, 0)
+	for _, key := range changed {
+		for _, prefix := range keys {
+			if key == prefix || strings.HasPrefix(key, prefix+".") {
+				result = append(result, key)
+				break
+			}
+		}
+	}
+	return result
+}
+
+//restore копирует в dst значение ключа path из src
+func restore(dst, src reflect.Value, path []string) {
+	for _, name := range path {
+		found := false
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Type().Field(i).Tag.Get("mapstructure") == name {
+				dst, src = dst.Field(i), src.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+	}
+	dst.Set(src)
+}
+
+//diff возвращает отсортированные ключи, значения которых различаются. Словари и списки сравниваются целиком
+func diff(old, new reflect.Value, path []string) []string {
+	changed := make([]string, 0)
+	if old.Kind() == reflect.Struct {
+		for i := 0; i < old.NumField(); i++ {
+			key := append(append([]string{}, path...), old.Type().Field(i).Tag.Get("mapstructure"))
+			changed = append(changed, diff(old.Field(i), new.Field(i), key)...)
+		}
+		sort.Strings(changed)
+		return changed
+	}
+	if !reflect.DeepEqual(old.Interface(), new.Interface()) {
+		changed = append(changed, strings.Join(path, "."))
+	}
+	return changed
+}
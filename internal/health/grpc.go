@@ -0,0 +1,58 @@
+package health
+
+import (
+	"calendar/internal/lifecycle"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"time"
+)
+
+//GRPCUpdater переносит результат проверок готовности в статус сервера gRPC health.
+//Статус выставляется и для всего сервера (пустое имя), и для каждого сервиса из Services
+type GRPCUpdater struct {
+	Checker  *Checker
+	Server   *grpchealth.Server
+	Services []string
+	Interval time.Duration
+	Logger   *zap.Logger
+}
+
+//Run обновляет статус каждые Interval до отмены ctx
+func (u *GRPCUpdater) Run(ctx context.Context) error {
+	//до первой проверки сервер не готов
+	u.set(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	serving := false
+	for {
+		err := u.Checker.Ready(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			if serving {
+				u.Logger.Error(fmt.Sprintf("Service is not ready: %v", err))
+			}
+			u.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			if !serving {
+				u.Logger.Info("Service is ready")
+			}
+			u.set(healthpb.HealthCheckResponse_SERVING)
+		}
+		serving = err == nil
+
+		if !lifecycle.Sleep(ctx, u.Interval) {
+			return nil
+		}
+	}
+}
+
+func (u *GRPCUpdater) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	u.Server.SetServingStatus("", status)
+	for _, service := range u.Services {
+		u.Server.SetServingStatus(service, status)
+	}
+}
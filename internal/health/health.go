@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+//CheckTimeout - сколько ждать одну проверку, зависшая база не должна вешать пробу
+const CheckTimeout = 3 * time.Second
+
+//Check возвращает ошибку, если компонент не работает
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+//Checker собирает проверки сервиса. Живость (/healthz) лечится перезапуском, готовность (/readyz) - нет
+type Checker struct {
+	mu        sync.RWMutex
+	liveness  []namedCheck
+	readiness []namedCheck
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+//AddLiveness добавляет проверку в /healthz и /readyz
+func (c *Checker) AddLiveness(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, namedCheck{name: name, check: check})
+}
+
+//AddReadiness добавляет проверку только в /readyz
+func (c *Checker) AddReadiness(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, namedCheck{name: name, check: check})
+}
+
+//Ready выполняет все проверки, по нему же обновляется статус gRPC health
+func (c *Checker) Ready(ctx context.Context) error {
+	return run(ctx, c.checks(true), nil)
+}
+
+//LiveHandler - обработчик /healthz
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(w, r, c.checks(false))
+	})
+}
+
+//ReadyHandler - обработчик /readyz
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(w, r, c.checks(true))
+	})
+}
+
+func (c *Checker) checks(readiness bool) []namedCheck {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	checks := append([]namedCheck{}, c.liveness...)
+	if readiness {
+		checks = append(checks, c.readiness...)
+	}
+	return checks
+}
+
+//serve отвечает 200 или 503 и построчно пишет результат каждой проверки
+func serve(w http.ResponseWriter, r *http.Request, checks []namedCheck) {
+	var report strings.Builder
+	err := run(r.Context(), checks, func(name string, err error) {
+		if err != nil {
+			fmt.Fprintf(&report, "%v: %v\n", name, err)
+		} else {
+			fmt.Fprintf(&report, "%v: ok\n", name)
+		}
+	})
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	fmt.Fprint(w, report.String())
+}
+
+//run выполняет проверки параллельно, каждую не дольше CheckTimeout.
+//Возвращает первую по порядку ошибку, report получает результаты в порядке добавления проверок
+func run(ctx context.Context, checks []namedCheck, report func(name string, err error)) error {
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c namedCheck) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+			defer cancel()
+			errs[i] = c.check(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	var first error
+	for i, c := range checks {
+		if report != nil {
+			report(c.name, errs[i])
+		}
+		if errs[i] != nil && first == nil {
+			first = fmt.Errorf("%v: %v", c.name, errs[i])
+		}
+	}
+	return first
+}
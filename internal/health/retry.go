@@ -0,0 +1,38 @@
+package health
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/lifecycle"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+//Retry вызывает connect, пока он не вернет nil, удваивая задержку от RetryDelay до MaxDelay.
+//Если за Timeout подключиться не удалось, возвращает последнюю ошибку. Отмена ctx прерывает ожидание
+func Retry(ctx context.Context, logger *zap.Logger, name string, config config.StartupConfig, connect func() error) error {
+	deadline := time.Now().Add(config.Timeout)
+	delay := config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			if attempt > 1 {
+				logger.Info(fmt.Sprintf("Connected to %v after %v attempts", name, attempt))
+			}
+			return nil
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("%v is not available after %v attempts: %v", name, attempt, err)
+		}
+
+		logger.Warn(fmt.Sprintf("%v is not available: %v, retry in %v", name, err, delay))
+		if !lifecycle.Sleep(ctx, delay) {
+			return fmt.Errorf("%v: startup cancelled: %v", name, err)
+		}
+		delay *= 2
+		if delay > config.MaxDelay {
+			delay = config.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var englishMonths = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var englishWeekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+//Locale - каталог сообщений и правила форматирования дат для одного языка
+type Locale struct {
+	Name     string
+	messages map[string]*template.Template
+	layouts  map[string]string
+	names    *strings.Replacer
+	fallback *Locale
+}
+
+//Bundle - набор локалей, загруженных из каталога. Неизвестные локали и отсутствующие
+//сообщения берутся из локали по умолчанию
+type Bundle struct {
+	locales  map[string]*Locale
+	fallback *Locale
+}
+
+//LoadBundle загружает все файлы <locale>.yaml из dir
+func LoadBundle(dir string, fallback string) (*Bundle, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{locales: make(map[string]*Locale)}
+	for _, file := range files {
+		locale, err := loadLocale(file)
+		if err != nil {
+			return nil, fmt.Errorf("locale %v: %v", file, err)
+		}
+		b.locales[locale.Name] = locale
+	}
+
+	b.fallback = b.locales[fallback]
+	if b.fallback == nil {
+		return nil, fmt.Errorf("default locale %v not found in %v", fallback, dir)
+	}
+	for _, locale := range b.locales {
+		if locale != b.fallback {
+			locale.fallback = b.fallback
+		}
+	}
+	return b, nil
+}
+
+func loadLocale(file string) (*Locale, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	locale := &Locale{
+		Name:     name,
+		messages: make(map[string]*template.Template),
+		layouts:  v.GetStringMapString("layouts"),
+	}
+
+	for key, text := range v.GetStringMapString("messages") {
+		tmpl, err := template.New(key).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		locale.messages[key] = tmpl
+	}
+
+	//названия месяцев и дней недели подставляются вместо английских из time.Format
+	months := v.GetStringSlice("months")
+	weekdays := v.GetStringSlice("weekdays")
+	pairs := make([]string, 0)
+	if len(months) == 12 {
+		for i, month := range months {
+			pairs = append(pairs, englishMonths[i], month)
+		}
+	}
+	if len(weekdays) == 7 {
+		for i, weekday := range weekdays {
+			pairs = append(pairs, englishWeekdays[i], weekday)
+		}
+	}
+	locale.names = strings.NewReplacer(pairs...)
+	return locale, nil
+}
+
+//Locale возвращает локаль по имени ("en", "ru", "en-US" ищется как "en") или локаль по умолчанию
+func (b *Bundle) Locale(name string) *Locale {
+	name = strings.ToLower(name)
+	if locale, ok := b.locales[name]; ok {
+		return locale
+	}
+	if i := strings.IndexAny(name, "-_"); i > 0 {
+		if locale, ok := b.locales[name[:i]]; ok {
+			return locale
+		}
+	}
+	return b.fallback
+}
+
+//Default возвращает локаль по умолчанию
+func (b *Bundle) Default() *Locale {
+	return b.fallback
+}
+
+//Message подставляет data в шаблон сообщения key
+func (l *Locale) Message(key string, data interface{}) (string, error) {
+	tmpl, ok := l.messages[key]
+	if !ok {
+		if l.fallback == nil {
+			return "", fmt.Errorf("message %v not found in locale %v", key, l.Name)
+		}
+		return l.fallback.Message(key, data)
+	}
+
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+//Format форматирует время в часовом поясе loc по макету layout ("date", "time" или "datetime")
+func (l *Locale) Format(t time.Time, loc *time.Location, layout string) string {
+	goLayout, ok := l.layouts[layout]
+	if !ok {
+		if l.fallback != nil {
+			return l.fallback.Format(t, loc, layout)
+		}
+		goLayout = time.RFC3339
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return l.names.Replace(t.Format(goLayout))
+}
+
+//FormatDuration выводит длительность в часах и минутах по шаблону сообщения duration
+func (l *Locale) FormatDuration(d time.Duration) string {
+	text, err := l.Message("duration", struct {
+		Hours   int
+		Minutes int
+	}{int(d.Hours()), int(d.Minutes()) % 60})
+	if err != nil {
+		return d.String()
+	}
+	return text
+}
@@ -0,0 +1,77 @@
+package memqueue
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"strings"
+	"sync"
+)
+
+type binding struct {
+	pattern []string
+	queue   *Queue
+}
+
+//Exchange раздает сообщения очередям по типу события, как topic exchange в RabbitMQ
+type Exchange struct {
+	mu       sync.RWMutex
+	bindings []binding
+}
+
+func NewExchange() *Exchange {
+	return &Exchange{}
+}
+
+//Bind направляет в queue сообщения, тип которых подходит под один из шаблонов
+func (e *Exchange) Bind(queue *Queue, patterns ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, pattern := range patterns {
+		e.bindings = append(e.bindings, binding{pattern: strings.Split(pattern, "."), queue: queue})
+	}
+}
+
+//Publish кладет сообщение в каждую подходящую очередь один раз
+func (e *Exchange) Publish(ctx context.Context, envelope structs.Envelope) error {
+	e.mu.RLock()
+	bindings := e.bindings
+	e.mu.RUnlock()
+
+	key := strings.Split(envelope.Type, ".")
+	sent := make(map[*Queue]bool)
+	for _, b := range bindings {
+		if sent[b.queue] || !matchTopic(b.pattern, key) {
+			continue
+		}
+		err := b.queue.Publish(ctx, envelope)
+		if err != nil {
+			return err
+		}
+		sent[b.queue] = true
+	}
+	return nil
+}
+
+//Close ничего не закрывает: очереди закрывают их читатели
+func (e *Exchange) Close() error {
+	return nil
+}
+
+func matchTopic(pattern []string, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if matchTopic(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && matchTopic(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && matchTopic(pattern[1:], key[1:])
+	}
+}
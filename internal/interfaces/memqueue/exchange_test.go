@@ -0,0 +1,34 @@
+package memqueue
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"event.created", "event.created", true},
+		{"event.created", "event.deleted", false},
+		{"event.*", "event.created", true},
+		{"event.*", "event", false},
+		{"event.*", "event.created.v2", false},
+		{"*.created", "event.created", true},
+		{"#", "event.created", true},
+		{"event.#", "event", true},
+		{"event.#", "event.created.v2", true},
+		{"#.v2", "event.created.v2", true},
+		{"#.v2", "event.created", false},
+		{"event.#.v2", "event.v2", true},
+		{"reminder.#", "event.created", false},
+		{"*", "event.created", false},
+	}
+	for _, tt := range tests {
+		if got := matchTopic(strings.Split(tt.pattern, "."), strings.Split(tt.key, ".")); got != tt.want {
+			t.Errorf("matchTopic(%v, %v) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package memqueue
+
+import (
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/structs"
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"sync"
+)
+
+//message хранит контекст спана отправителя, чтобы обработчик продолжил его трассу
+type message struct {
+	span     trace.SpanContext
+	envelope structs.Envelope
+}
+
+//Queue - очередь внутри процесса на каналах. Используется в режиме одного бинарника и в тестах
+type Queue struct {
+	storage chan message
+	done    chan struct{}
+	once    sync.Once
+	logger  *zap.Logger
+}
+
+var ErrClosed = errors.New("queue is closed")
+
+func NewQueue(logger *zap.Logger, size int) *Queue {
+	return &Queue{
+		storage: make(chan message, size),
+		done:    make(chan struct{}),
+		logger:  logger,
+	}
+}
+
+func (q *Queue) Publish(ctx context.Context, envelope structs.Envelope) error {
+	select {
+	case <-q.done:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case <-q.done:
+		return ErrClosed
+	case q.storage <- message{span: trace.SpanContextFromContext(ctx), envelope: envelope}:
+		q.logger.Debug(fmt.Sprintf(" [x] Sent %v", envelope))
+		return nil
+	}
+}
+
+func (q *Queue) Receive(handler queue.Handler) error {
+	q.logger.Info("Receiver Waiting for messages.")
+	for {
+		select {
+		case <-q.done:
+			return nil
+		case msg := <-q.storage:
+			err := handler(trace.ContextWithRemoteSpanContext(context.Background(), msg.span), msg.envelope)
+			if err != nil {
+				q.logger.Error(fmt.Sprintf("Handler error %v", err))
+			}
+		}
+	}
+}
+
+func (q *Queue) Close() error {
+	q.once.Do(func() {
+		close(q.done)
+	})
+	return nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"calendar/internal/metrics"
+	"calendar/internal/tracing"
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Duration of PSQL methods, including all queries and the transaction they run.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method"})
+
+var txRetries = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "db",
+	Name:      "transaction_retries_total",
+	Help:      "Transactions repeated after a serialization failure or deadlock.",
+})
+
+var tracer = tracing.Tracer("postgres")
+
+//observe замеряет длительность метода и пишет его спан в трассу ctx. Запросы метода выполняются с возвращенным контекстом:
+//ctx, end := db.observe(ctx, "GetEvents"); defer end()
+func (db *PSQL) observe(ctx context.Context, method string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "PSQL."+method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("db.system", "postgresql")))
+	return ctx, func() {
+		span.End()
+		queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//firstMigration - номер первого файла схемы. Файлы с номером 1 создают роль и базу,
+//их выполняет образ postgres от имени суперпользователя
+const firstMigration = 2
+
+var migrationName = regexp.MustCompile(`^(\d+)[^/]*\.sql$`)
+
+type migration struct {
+	number int
+	name   string
+	path   string
+}
+
+//Migrate выполняет новые файлы схемы из dir по порядку номеров. Файлы не больше baseline только отмечаются
+//примененными: их уже выполнил образ postgres из docker-compose
+func (db *PSQL) Migrate(ctx context.Context, dir string, baseline int) ([]string, error) {
+	migrations, err := readMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS public.schema_migrations
+		(name text NOT NULL PRIMARY KEY, applied_at timestamp without time zone NOT NULL DEFAULT now())`)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]string, 0)
+	for _, m := range migrations {
+		ok, err := db.applyMigration(ctx, m, m.number <= baseline)
+		if err != nil {
+			return applied, fmt.Errorf("migration %v: %v", m.name, err)
+		}
+		if ok {
+			applied = append(applied, m.name)
+		}
+	}
+	return applied, nil
+}
+
+//applyMigration выполняет файл, если он еще не применен. Блокировка не дает двум запускам выполнить его дважды
+func (db *PSQL) applyMigration(ctx context.Context, m migration, skip bool) (bool, error) {
+	applied := false
+	err := db.transaction(ctx, sql.LevelReadCommitted, func(tx *sqlx.Tx) error {
+		applied = false
+		_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('schema_migrations'))")
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = tx.GetContext(ctx, &count, "SELECT count(*) FROM public.schema_migrations WHERE name = $1", m.name)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if !skip {
+			script, err := os.ReadFile(m.path)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, string(script))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO public.schema_migrations (name) VALUES ($1)", m.name)
+		if err != nil {
+			return err
+		}
+		applied = !skip
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+func readMigrations(dir string) ([]migration, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0)
+	for _, f := range files {
+		match := migrationName.FindStringSubmatch(f.Name())
+		if f.IsDir() || match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil || number < firstMigration {
+			continue
+		}
+		migrations = append(migrations, migration{number: number, name: f.Name(), path: filepath.Join(dir, f.Name())})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		if migrations[i].number != migrations[j].number {
+			return migrations[i].number < migrations[j].number
+		}
+		return migrations[i].name < migrations[j].name
+	})
+	return migrations, nil
+}
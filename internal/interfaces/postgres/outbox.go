@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"sort"
+	"time"
+)
+
+type outboxRecord struct {
+	Id      int64  `db:"id"`
+	Payload []byte `db:"payload"`
+}
+
+//insertOutbox пишет запись об изменении в outbox в той же транзакции, что и само изменение
+func insertOutbox(ctx context.Context, tx *sqlx.Tx, envelope structs.Envelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO public.outbox (message_id, type, payload, occurred_at) VALUES ($1, $2, $3, $4)",
+		envelope.MessageId, envelope.Type, payload, envelope.OccurredAt)
+	return err
+}
+
+//RelayOutbox занимает пакет записей на lease и публикует без открытой транзакции, порядок соблюдается внутри пакета.
+//Запись, которую не удалось разобрать, помечается failed_at и остается в outbox для разбора
+func (db *PSQL) RelayOutbox(ctx context.Context, limit int, now time.Time, lease time.Duration, publish func(envelope structs.Envelope) error) (int, error) {
+	ctx, end := db.observe(ctx, "RelayOutbox")
+	defer end()
+
+	var records []outboxRecord
+	err := db.conn.SelectContext(ctx, &records, `WITH due AS (
+			SELECT id FROM public.outbox
+			WHERE published_at IS NULL AND failed_at IS NULL AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY id LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE public.outbox o SET claimed_until = $3
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.payload`,
+		now, limit, now.Add(lease))
+	if err != nil {
+		return 0, err
+	}
+	//RETURNING не сохраняет порядок подзапроса
+	sort.Slice(records, func(i, j int) bool { return records[i].Id < records[j].Id })
+
+	published := 0
+	for i, record := range records {
+		var envelope structs.Envelope
+		decodeErr := json.Unmarshal(record.Payload, &envelope)
+		if decodeErr != nil {
+			db.logger.Error(fmt.Sprintf("Outbox record %v is malformed, marked as failed: %v", record.Id, decodeErr))
+			_, err = db.conn.ExecContext(ctx, "UPDATE public.outbox SET failed_at = $1, error = $2 WHERE id = $3", time.Now().UTC(), decodeErr.Error(), record.Id)
+			if err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		publishErr := publish(envelope)
+		if publishErr != nil {
+			db.releaseOutbox(ctx, records[i:])
+			return published, publishErr
+		}
+
+		//если отметка не записалась, запись уйдет еще раз после lease, доставка и так at-least-once
+		_, err = db.conn.ExecContext(ctx, "UPDATE public.outbox SET published_at = $1 WHERE id = $2", time.Now().UTC(), record.Id)
+		if err != nil {
+			db.releaseOutbox(ctx, records[i+1:])
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+//releaseOutbox снимает занятость с неопубликованных записей пакета, чтобы следующий вызов взял их сразу
+func (db *PSQL) releaseOutbox(ctx context.Context, records []outboxRecord) {
+	if len(records) == 0 {
+		return
+	}
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+	}
+	_, err := db.conn.ExecContext(ctx, "UPDATE public.outbox SET claimed_until = NULL WHERE id = ANY($1) AND published_at IS NULL", pq.Array(ids))
+	if err != nil {
+		db.logger.Error(fmt.Sprintf("Release outbox records error %v", err))
+	}
+}
+
+//CleanupOutbox удаляет записи, опубликованные раньше before
+func (db *PSQL) CleanupOutbox(ctx context.Context, before time.Time) (int64, error) {
+	ctx, end := db.observe(ctx, "CleanupOutbox")
+	defer end()
+	result, err := db.conn.ExecContext(ctx, "DELETE FROM public.outbox WHERE published_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
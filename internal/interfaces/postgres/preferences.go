@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"time"
+)
+
+type preferencesRow struct {
+	Owner           string         `db:"owner"`
+	Channels        pq.StringArray `db:"channels"`
+	Email           string         `db:"email"`
+	WebhookURL      string         `db:"webhook_url"`
+	Locale          string         `db:"locale"`
+	TimeZone        string         `db:"timezone"`
+	ReminderOffsets pq.Int32Array  `db:"reminder_offsets"`
+	QuietHoursStart string         `db:"quiet_hours_start"`
+	QuietHoursEnd   string         `db:"quiet_hours_end"`
+	DailyCap        int32          `db:"daily_cap"`
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
+
+func (row preferencesRow) preferences() structs.NotificationPreferences {
+	return structs.NotificationPreferences{
+		Owner:           row.Owner,
+		Channels:        []string(row.Channels),
+		Email:           row.Email,
+		WebhookURL:      row.WebhookURL,
+		Locale:          row.Locale,
+		TimeZone:        row.TimeZone,
+		ReminderOffsets: []int32(row.ReminderOffsets),
+		QuietHoursStart: row.QuietHoursStart,
+		QuietHoursEnd:   row.QuietHoursEnd,
+		DailyCap:        row.DailyCap,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+//GetNotificationPreferences возвращает настройки владельца. ok=false, если они не сохранены
+func (db *PSQL) GetNotificationPreferences(ctx context.Context, owner string) (structs.NotificationPreferences, bool, error) {
+	ctx, end := db.observe(ctx, "GetNotificationPreferences")
+	defer end()
+	var rows []preferencesRow
+	err := db.conn.SelectContext(ctx, &rows, `SELECT owner, channels, email, webhook_url, locale, timezone, reminder_offsets,
+			quiet_hours_start, quiet_hours_end, daily_cap, updated_at
+		FROM public.notification_preferences WHERE owner = $1`, owner)
+	if err != nil {
+		db.logger.Error(err.Error())
+		return structs.NotificationPreferences{}, false, err
+	}
+	if len(rows) == 0 {
+		return structs.NotificationPreferences{}, false, nil
+	}
+	return rows[0].preferences(), true, nil
+}
+
+//SaveNotificationPreferences создает или заменяет настройки владельца
+func (db *PSQL) SaveNotificationPreferences(ctx context.Context, prefs structs.NotificationPreferences) error {
+	ctx, end := db.observe(ctx, "SaveNotificationPreferences")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, `INSERT INTO public.notification_preferences (owner, channels, email, webhook_url, locale, timezone,
+			reminder_offsets, quiet_hours_start, quiet_hours_end, daily_cap, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (owner) DO UPDATE SET channels = EXCLUDED.channels, email = EXCLUDED.email, webhook_url = EXCLUDED.webhook_url,
+			locale = EXCLUDED.locale, timezone = EXCLUDED.timezone, reminder_offsets = EXCLUDED.reminder_offsets,
+			quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end,
+			daily_cap = EXCLUDED.daily_cap, updated_at = EXCLUDED.updated_at`,
+		prefs.Owner, pq.Array(prefs.Channels), prefs.Email, prefs.WebhookURL, prefs.Locale, prefs.TimeZone,
+		pq.Array(prefs.ReminderOffsets), prefs.QuietHoursStart, prefs.QuietHoursEnd, prefs.DailyCap, prefs.UpdatedAt)
+	return err
+}
+
+//ReserveDailyNotification увеличивает счетчик оповещений владельца за день, если он меньше limit.
+//Возвращает false, если лимит уже исчерпан
+func (db *PSQL) ReserveDailyNotification(ctx context.Context, owner string, day time.Time, limit int32) (bool, error) {
+	ctx, end := db.observe(ctx, "ReserveDailyNotification")
+	defer end()
+	var sent []int32
+	err := db.conn.SelectContext(ctx, &sent, `INSERT INTO public.notification_counters (owner, day, sent) VALUES ($1, $2, 1)
+		ON CONFLICT (owner, day) DO UPDATE SET sent = notification_counters.sent + 1
+		WHERE notification_counters.sent < $3
+		RETURNING sent`, owner, day.Format("2006-01-02"), limit)
+	if err != nil {
+		return false, err
+	}
+	return len(sent) > 0, nil
+}
+
+//ReleaseDailyNotification уменьшает счетчик оповещений владельца за день
+func (db *PSQL) ReleaseDailyNotification(ctx context.Context, owner string, day time.Time) error {
+	ctx, end := db.observe(ctx, "ReleaseDailyNotification")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, "UPDATE public.notification_counters SET sent = sent - 1 WHERE owner = $1 AND day = $2 AND sent > 0",
+		owner, day.Format("2006-01-02"))
+	return err
+}
+
+//DeferReminder сохраняет напоминание для доставки в until
+func (db *PSQL) DeferReminder(ctx context.Context, event structs.Event, reminder structs.Reminder, until time.Time, reason string) error {
+	ctx, end := db.observe(ctx, "DeferReminder")
+	defer end()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	reminderPayload, err := json.Marshal(reminder)
+	if err != nil {
+		return err
+	}
+	_, err = db.conn.ExecContext(ctx, "INSERT INTO public.deferred_reminders (owner, event, reminder, reason, deliver_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		event.Owner, payload, reminderPayload, reason, until.UTC(), time.Now().UTC())
+	return err
+}
+
+type deferredReminder struct {
+	Id       int64  `db:"id"`
+	Event    []byte `db:"event"`
+	Reminder []byte `db:"reminder"`
+	Attempts int    `db:"attempts"`
+}
+
+//ProcessDeferredReminders занимает напоминания сдвигом deliver_at на retryDelay и доставляет без открытой транзакции.
+//После maxAttempts попыток напоминание помечается failed_at и остается в таблице для разбора
+func (db *PSQL) ProcessDeferredReminders(ctx context.Context, limit int, now time.Time, retryDelay time.Duration, maxAttempts int, deliver func(event structs.Event, reminder structs.Reminder) error) (int, error) {
+	ctx, end := db.observe(ctx, "ProcessDeferredReminders")
+	defer end()
+
+	var reminders []deferredReminder
+	err := db.conn.SelectContext(ctx, &reminders, `WITH due AS (
+			SELECT id FROM public.deferred_reminders
+			WHERE failed_at IS NULL AND deliver_at <= $1
+			ORDER BY deliver_at LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE public.deferred_reminders d SET deliver_at = $3, attempts = d.attempts + 1
+		FROM due WHERE d.id = due.id
+		RETURNING d.id, d.event, d.reminder, d.attempts`, now.UTC(), limit, now.Add(retryDelay).UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	var recordErr error
+	for _, reminder := range reminders {
+		err = deliverDeferred(reminder, deliver)
+		switch {
+		case err == nil:
+			_, err = db.conn.ExecContext(ctx, "DELETE FROM public.deferred_reminders WHERE id = $1", reminder.Id)
+			if err == nil {
+				delivered++
+			}
+		case reminder.Attempts >= maxAttempts:
+			db.logger.Error(fmt.Sprintf("Deferred reminder %v failed after %v attempts: %v", reminder.Id, reminder.Attempts, err))
+			_, err = db.conn.ExecContext(ctx, "UPDATE public.deferred_reminders SET error = $1, failed_at = $2 WHERE id = $3",
+				err.Error(), time.Now().UTC(), reminder.Id)
+		default:
+			db.logger.Error(fmt.Sprintf("Deferred reminder %v attempt %v failed: %v", reminder.Id, reminder.Attempts, err))
+			_, err = db.conn.ExecContext(ctx, "UPDATE public.deferred_reminders SET error = $1, deliver_at = $2 WHERE id = $3",
+				err.Error(), time.Now().Add(retryDelay).UTC(), reminder.Id)
+		}
+		//остальные напоминания уже заняты, поэтому доставляем их, а это вернется в работу через retryDelay
+		if err != nil && recordErr == nil {
+			recordErr = err
+		}
+	}
+	return delivered, recordErr
+}
+
+func deliverDeferred(reminder deferredReminder, deliver func(event structs.Event, reminder structs.Reminder) error) error {
+	var event structs.Event
+	err := json.Unmarshal(reminder.Event, &event)
+	if err != nil {
+		return err
+	}
+	info := structs.DefaultReminder(event)
+	if reminder.Reminder != nil {
+		err = json.Unmarshal(reminder.Reminder, &info)
+		if err != nil {
+			return err
+		}
+	}
+	return deliver(event, info)
+}
@@ -1,19 +1,23 @@
 package postgres
 
 import (
+	"calendar/internal/config"
 	"calendar/internal/structs"
-	"errors"
+	"calendar/internal/tracing"
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
+	"strings"
 	"time"
 )
 
 type PSQL struct {
 	conn   sqlx.DB
 	logger *zap.Logger
-	config map[string]interface{}
 }
 
 type PSQLChangeEvent struct {
@@ -21,17 +25,26 @@ type PSQLChangeEvent struct {
 	UUID  string
 }
 
-func NewPSQL(logger *zap.Logger, config map[string]interface{}) (PSQL, error) {
+//PSQLReminder - событие и конкретное напоминание о нем.
+//TraceContext - traceparent запроса, который последним создал или изменил событие
+type PSQLReminder struct {
+	Event        structs.Event
+	Reminder     structs.Reminder
+	TraceContext string
+}
+
+type reminderRow struct {
+	structs.Event
+	Offset       int32  `db:"reminder_offset"`
+	TraceContext string `db:"trace_context"`
+}
 
-	user := config["user"]
-	password := config["password"]
-	sslmode := config["sslmode"]
-	host := config["host"]
-	dbname := config["dbname"]
+func NewPSQL(logger *zap.Logger, config config.DBConfig) (PSQL, error) {
 
-	logger.Info(fmt.Sprintf("user: %v password: %v sslmode: %v host: %v dbname: %v config: %v", user, password, sslmode, host, dbname, config))
+	//пароль передается только драйверу и в лог не попадает
+	logger.Info(fmt.Sprintf("Connecting to postgres user: %v host: %v dbname: %v sslmode: %v", config.User, config.Host, config.DBName, config.SSLMode))
 
-	db, err := sqlx.Connect("postgres", fmt.Sprintf("user=%v password=%v host=%v dbname=%v sslmode=%v", user, password, host, dbname, sslmode))
+	db, err := sqlx.Connect("postgres", dataSourceName(config))
 	if err != nil {
 		return PSQL{}, err
 	}
@@ -39,98 +52,116 @@ func NewPSQL(logger *zap.Logger, config map[string]interface{}) (PSQL, error) {
 	ps := PSQL{
 		conn:   *db,
 		logger: logger,
-		config: config,
 	}
 
 	return ps, nil
 }
 
+//dataSourceName собирает строку подключения key=value. Значения берутся в кавычки, иначе пробел или кавычка
+//в пароле ломают разбор, и ошибка драйвера печатает часть пароля
+func dataSourceName(config config.DBConfig) string {
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return fmt.Sprintf("user='%v' password='%v' host='%v' dbname='%v' sslmode='%v'",
+		quote.Replace(config.User), quote.Replace(config.Password.Value()), quote.Replace(config.Host),
+		quote.Replace(config.DBName), quote.Replace(config.SSLMode))
+}
+
+//Ping проверяет, что база принимает запросы. Используется в проверке готовности
+func (db *PSQL) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
 func (db *PSQL) Close() error {
-	err := db.Close()
+	err := db.conn.Close()
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (db *PSQL) InsertEvent(event structs.Event) (bool, error) {
-	identifier, err := db.GetEventIdByUUID(event.UUID)
-	if err != nil {
-		return false, err
-	}
-
-	if identifier != 0 {
-
-		return false, errors.New(fmt.Sprintf("Event with UUID %v already exyist in DB", event.UUID))
-	}
-
-	cursor := db.conn.MustBegin()
-	cursor.MustExec("INSERT INTO public.events (uuid, header, datetime, description, owner, eventduration_start, eventduration_stop, mailingduration) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
-		event.UUID, event.Header, event.DateTime, event.Description, event.Owner, event.EventDurationStart, event.EventDurationStop, event.MailingDuration)
-	err = cursor.Commit()
+//дубликат UUID отсекает уникальный индекс, поэтому два одновременных запроса не создадут два события
+func (db *PSQL) InsertEvent(ctx context.Context, event structs.Event) (bool, error) {
+	ctx, end := db.observe(ctx, "InsertEvent")
+	defer end()
+	err := db.transaction(ctx, sql.LevelSerializable, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, `INSERT INTO public.events (uuid, header, datetime, description, owner, eventduration_start, eventduration_stop, mailingduration, urgent, trace_context)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (uuid) DO NOTHING`,
+			event.UUID, event.Header, event.DateTime, event.Description, event.Owner, event.EventDurationStart, event.EventDurationStop, event.MailingDuration, event.Urgent, tracing.Traceparent(ctx))
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("Event with UUID %v already exist in DB", event.UUID)
+		}
+		return insertOutbox(ctx, tx, structs.NewEnvelope(structs.EventCreated, event))
+	})
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (db *PSQL) UpdateEvent(req PSQLChangeEvent) (bool, error) {
-	identifier, err := db.GetEventIdByUUID(req.UUID)
-	if err != nil {
-		return false, err
-	}
-
-	if identifier != 0 {
-		return false, errors.New(fmt.Sprintf("Event with UUID %v not exist in DB", req.UUID))
-	}
-
-	cursor := db.conn.MustBegin()
-	cursor.MustExec("UPDATE public.events SET uuid=$1, header=$2, datetime=$3, description=$4, owner=$5, eventduration_start=$6, eventduration_stop=$7, mailingduration=$8 where id = $9",
-		req.Event.UUID, req.Event.Header, req.Event.DateTime, req.Event.Description, req.Event.Owner, req.Event.EventDurationStart, req.Event.EventDurationStop, req.Event.MailingDuration, identifier)
-	err = cursor.Commit()
+func (db *PSQL) UpdateEvent(ctx context.Context, req PSQLChangeEvent) (bool, error) {
+	ctx, end := db.observe(ctx, "UpdateEvent")
+	defer end()
+	err := db.transaction(ctx, sql.LevelSerializable, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE public.events SET uuid=$1, header=$2, datetime=$3, description=$4, owner=$5, eventduration_start=$6, eventduration_stop=$7, mailingduration=$8, urgent=$9, trace_context=$10 WHERE uuid = $11",
+			req.Event.UUID, req.Event.Header, req.Event.DateTime, req.Event.Description, req.Event.Owner, req.Event.EventDurationStart, req.Event.EventDurationStop, req.Event.MailingDuration, req.Event.Urgent, tracing.Traceparent(ctx), req.UUID)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("Event with UUID %v already exist in DB", req.Event.UUID)
+		}
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("Event with UUID %v not exist in DB", req.UUID)
+		}
+		return insertOutbox(ctx, tx, structs.NewEnvelope(structs.EventUpdated, req.Event))
+	})
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (db *PSQL) RemoveEvent(req PSQLChangeEvent) (bool, error) {
-	identifier, err := db.GetEventIdByUUID(req.UUID)
-	if err != nil {
-		return false, err
-	}
-
-	if identifier != 0 {
-		return false, errors.New(fmt.Sprintf("Event with UUID %v not exist in DB", req.UUID))
-	}
-	cursor := db.conn.MustBegin()
-	cursor.MustExec("DELETE FROM public.events WHERE id = $1;", identifier)
-	err = cursor.Commit()
+func (db *PSQL) RemoveEvent(ctx context.Context, req PSQLChangeEvent) (bool, error) {
+	ctx, end := db.observe(ctx, "RemoveEvent")
+	defer end()
+	err := db.transaction(ctx, sql.LevelSerializable, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM public.events WHERE uuid = $1", req.UUID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("Event with UUID %v not exist in DB", req.UUID)
+		}
+		removed := req.Event
+		removed.UUID = req.UUID
+		return insertOutbox(ctx, tx, structs.NewEnvelope(structs.EventDeleted, removed))
+	})
 	if err != nil {
 		return false, err
 	}
 	return true, nil
-
 }
 
-func (db *PSQL) GetEventIdByUUID(uuid string) (int, error) {
-	var identifier []int
-	err := db.conn.Select(&identifier, "SELECT id FROM public.events where uuid = $1", uuid)
-	if err != nil {
-		db.logger.Error(err.Error())
-		return 0, err
-	}
-	if len(identifier) > 0 {
-		return identifier[0], nil
-	} else {
-		return 0, nil
-	}
-
-}
-
-func (db *PSQL) GetEvents(start time.Time, stop time.Time) ([]structs.Event, error) {
+func (db *PSQL) GetEvents(ctx context.Context, start time.Time, stop time.Time) ([]structs.Event, error) {
+	ctx, end := db.observe(ctx, "GetEvents")
+	defer end()
 	var selectResult []structs.Event
-	err := db.conn.Select(&selectResult, "SELECT uuid, header, datetime, description, owner, eventduration_start, eventduration_stop, mailingduration FROM public.events where datetime >= $1 and datetime <= $2",
+	err := db.conn.SelectContext(ctx, &selectResult, "SELECT uuid, header, datetime, description, owner, eventduration_start, eventduration_stop, mailingduration, urgent FROM public.events where datetime >= $1 and datetime <= $2",
 		start, stop)
 	if err != nil {
 		db.logger.Error(err.Error())
@@ -144,18 +175,40 @@ func (db *PSQL) GetEvents(start time.Time, stop time.Time) ([]structs.Event, err
 	}
 }
 
-func (db *PSQL) GetPublishEvents(start time.Time, stop time.Time) ([]structs.Event, error) {
-	var selectResult []structs.Event
-	err := db.conn.Select(&selectResult, "SELECT uuid, header, datetime, description, owner, eventduration_start, eventduration_stop, mailingduration FROM public.events where eventduration_start >= $1 and eventduration_start < $2",
-		start, stop)
+//GetPublishReminders возвращает напоминания на [start, stop): за mailingduration, иначе за смещения владельца.
+//ownerOffsets - смещения из конфига для владельцев без настроек в базе, ключи в нижнем регистре
+func (db *PSQL) GetPublishReminders(ctx context.Context, start time.Time, stop time.Time, ownerOffsets map[string][]int32) ([]PSQLReminder, error) {
+	ctx, end := db.observe(ctx, "GetPublishReminders")
+	defer end()
+	offsets, err := json.Marshal(ownerOffsets)
+	if err != nil {
+		return nil, err
+	}
+	var rows []reminderRow
+	err = db.conn.SelectContext(ctx, &rows, `SELECT e.uuid, e.header, e.datetime, e.description, e.owner, e.eventduration_start, e.eventduration_stop, e.mailingduration, e.urgent,
+			o.minutes AS reminder_offset, coalesce(e.trace_context, '') AS trace_context
+		FROM public.events e
+		LEFT JOIN public.notification_preferences p ON p.owner = e.owner
+		CROSS JOIN LATERAL unnest(CASE
+			WHEN e.mailingduration > 0 THEN ARRAY[e.mailingduration::integer]
+			WHEN cardinality(p.reminder_offsets) > 0 THEN p.reminder_offsets
+			WHEN p.owner IS NULL AND $3::jsonb -> lower(e.owner) IS NOT NULL
+				THEN ARRAY(SELECT jsonb_array_elements_text($3::jsonb -> lower(e.owner))::integer)
+			ELSE ARRAY[0] END) AS o(minutes)
+		WHERE e.eventduration_start - o.minutes * interval '1 minute' >= $1 AND e.eventduration_start - o.minutes * interval '1 minute' < $2`,
+		start, stop, string(offsets))
 	if err != nil {
 		db.logger.Error(err.Error())
 		return nil, err
 	}
-	if len(selectResult) > 0 {
 
-		return selectResult, nil
-	} else {
-		return nil, nil
+	reminders := make([]PSQLReminder, 0, len(rows))
+	for _, row := range rows {
+		reminders = append(reminders, PSQLReminder{
+			Event:        row.Event,
+			Reminder:     structs.Reminder{Occurrence: row.Event.EventDurationStart, Offset: row.Offset},
+			TraceContext: row.TraceContext,
+		})
 	}
+	return reminders, nil
 }
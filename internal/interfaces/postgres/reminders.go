@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"time"
+)
+
+//RecordReminderDelivery пишет результат доставки напоминания в журнал
+func (db *PSQL) RecordReminderDelivery(ctx context.Context, delivery structs.ReminderDelivery) error {
+	ctx, end := db.observe(ctx, "RecordReminderDelivery")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, "INSERT INTO public.reminder_deliveries (reminder_id, event_uuid, owner, channel, status, error, attempted_at, deferred_to) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		delivery.ReminderId, delivery.EventUUID, delivery.Owner, delivery.Channel, delivery.Status, delivery.Error, delivery.AttemptedAt, delivery.DeferredTo)
+	return err
+}
+
+//GetReminderDeliveries возвращает журнал доставок напоминаний о событии, новые первыми
+func (db *PSQL) GetReminderDeliveries(ctx context.Context, eventUUID string, limit int) ([]structs.ReminderDelivery, error) {
+	ctx, end := db.observe(ctx, "GetReminderDeliveries")
+	defer end()
+	var deliveries []structs.ReminderDelivery
+	err := db.conn.SelectContext(ctx, &deliveries, `SELECT id, reminder_id, event_uuid, owner, channel, status, error, attempted_at, deferred_to
+		FROM public.reminder_deliveries WHERE event_uuid = $1 ORDER BY attempted_at DESC, id DESC LIMIT $2`,
+		eventUUID, limit)
+	if err != nil {
+		db.logger.Error(err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+//ClaimReminder занимает id напоминания на время отправки lease. Просроченная запись занимается заново.
+//Возвращает false, если напоминание уже отправлено, и structs.ErrReminderSending, если его отправляет другой нотификатор
+func (db *PSQL) ClaimReminder(ctx context.Context, id string, lease time.Duration) (bool, error) {
+	ctx, end := db.observe(ctx, "ClaimReminder")
+	defer end()
+	now := time.Now().UTC()
+	var claimed []string
+	err := db.conn.SelectContext(ctx, &claimed, `INSERT INTO public.reminder_dedup (reminder_id, expires_at, sent) VALUES ($1, $2, false)
+		ON CONFLICT (reminder_id) DO UPDATE SET expires_at = EXCLUDED.expires_at, sent = false
+		WHERE reminder_dedup.expires_at <= $3
+		RETURNING reminder_id`, id, now.Add(lease), now)
+	if err != nil {
+		return false, err
+	}
+	if len(claimed) > 0 {
+		return true, nil
+	}
+
+	//запись могла удалить очистка между запросами, тогда повтор займет ее заново
+	var sent []bool
+	err = db.conn.SelectContext(ctx, &sent, "SELECT sent FROM public.reminder_dedup WHERE reminder_id = $1", id)
+	if err != nil {
+		return false, err
+	}
+	if len(sent) == 0 || !sent[0] {
+		return false, structs.ErrReminderSending
+	}
+	return false, nil
+}
+
+//ConfirmReminder отмечает напоминание отправленным на ttl. Если запись уже удалила очистка, она создается заново
+func (db *PSQL) ConfirmReminder(ctx context.Context, id string, ttl time.Duration) error {
+	ctx, end := db.observe(ctx, "ConfirmReminder")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, `INSERT INTO public.reminder_dedup (reminder_id, expires_at, sent) VALUES ($1, $2, true)
+		ON CONFLICT (reminder_id) DO UPDATE SET expires_at = EXCLUDED.expires_at, sent = true`, id, time.Now().UTC().Add(ttl))
+	return err
+}
+
+func (db *PSQL) ReleaseReminder(ctx context.Context, id string) error {
+	ctx, end := db.observe(ctx, "ReleaseReminder")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, "DELETE FROM public.reminder_dedup WHERE reminder_id = $1", id)
+	return err
+}
+
+//CleanupReminderDedup удаляет просроченные записи и возвращает их количество
+func (db *PSQL) CleanupReminderDedup(ctx context.Context, now time.Time) (int64, error) {
+	ctx, end := db.observe(ctx, "CleanupReminderDedup")
+	defer end()
+	result, err := db.conn.ExecContext(ctx, "DELETE FROM public.reminder_dedup WHERE expires_at <= $1", now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"calendar/internal/lifecycle"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+//txAttempts - сколько раз выполнять транзакцию, которую база отменила из-за конфликта с параллельной
+const txAttempts = 3
+
+//txRetryDelay - задержка перед первым повтором, дальше удваивается
+const txRetryDelay = 20 * time.Millisecond
+
+//коды ошибок postgres, после которых транзакцию можно просто повторить
+const (
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+//codeUniqueViolation - запрос нарушил уникальный индекс, повтор не поможет
+const codeUniqueViolation = "23505"
+
+//transaction повторяет fn целиком после конфликта сериализации или взаимной блокировки,
+//поэтому fn должна заново вычислять все, что возвращает
+func (db *PSQL) transaction(ctx context.Context, isolation sql.IsolationLevel, fn func(tx *sqlx.Tx) error) error {
+	delay := txRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := db.runTransaction(ctx, isolation, fn)
+		if err == nil || !retryable(err) || attempt == txAttempts {
+			return err
+		}
+
+		txRetries.Inc()
+		db.logger.Info(fmt.Sprintf("Transaction conflict, retry %v of %v: %v", attempt, txAttempts-1, err))
+		if !lifecycle.Sleep(ctx, delay) {
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+func (db *PSQL) runTransaction(ctx context.Context, isolation sql.IsolationLevel, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.conn.BeginTxx(ctx, &sql.TxOptions{Isolation: isolation})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == codeSerializationFailure || pqErr.Code == codeDeadlockDetected
+}
+
+//isUniqueViolation проверяет, что запрос нарушил уникальный индекс
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == codeUniqueViolation
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"time"
+)
+
+type webhookSubscriptionRow struct {
+	UUID       string         `db:"uuid"`
+	Owner      string         `db:"owner"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (row webhookSubscriptionRow) subscription() structs.WebhookSubscription {
+	return structs.WebhookSubscription{
+		UUID:       row.UUID,
+		Owner:      row.Owner,
+		URL:        row.URL,
+		Secret:     row.Secret,
+		EventTypes: []string(row.EventTypes),
+		Active:     row.Active,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+const webhookSubscriptionColumns = "uuid, coalesce(owner, '') AS owner, url, secret, event_types, active, created_at"
+
+func (db *PSQL) InsertWebhook(ctx context.Context, sub structs.WebhookSubscription) error {
+	ctx, end := db.observe(ctx, "InsertWebhook")
+	defer end()
+	_, err := db.conn.ExecContext(ctx, "INSERT INTO public.webhook_subscriptions (uuid, owner, url, secret, event_types, active, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		sub.UUID, sub.Owner, sub.URL, sub.Secret, pq.Array(sub.EventTypes), sub.Active, sub.CreatedAt)
+	return err
+}
+
+func (db *PSQL) UpdateWebhook(ctx context.Context, sub structs.WebhookSubscription) error {
+	ctx, end := db.observe(ctx, "UpdateWebhook")
+	defer end()
+	result, err := db.conn.ExecContext(ctx, "UPDATE public.webhook_subscriptions SET owner=$1, url=$2, secret=$3, event_types=$4, active=$5 WHERE uuid = $6",
+		sub.Owner, sub.URL, sub.Secret, pq.Array(sub.EventTypes), sub.Active, sub.UUID)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result.RowsAffected())
+}
+
+//RemoveWebhook удаляет подписку вместе с журналом ее доставок
+func (db *PSQL) RemoveWebhook(ctx context.Context, uuid string) error {
+	ctx, end := db.observe(ctx, "RemoveWebhook")
+	defer end()
+	result, err := db.conn.ExecContext(ctx, "DELETE FROM public.webhook_subscriptions WHERE uuid = $1", uuid)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result.RowsAffected())
+}
+
+func (db *PSQL) GetWebhook(ctx context.Context, uuid string) (structs.WebhookSubscription, error) {
+	ctx, end := db.observe(ctx, "GetWebhook")
+	defer end()
+	var rows []webhookSubscriptionRow
+	err := db.conn.SelectContext(ctx, &rows, "SELECT "+webhookSubscriptionColumns+" FROM public.webhook_subscriptions WHERE uuid = $1", uuid)
+	if err != nil {
+		db.logger.Error(err.Error())
+		return structs.WebhookSubscription{}, err
+	}
+	if len(rows) == 0 {
+		return structs.WebhookSubscription{}, fmt.Errorf("Webhook with UUID %v not exist in DB", uuid)
+	}
+	return rows[0].subscription(), nil
+}
+
+//GetWebhooks возвращает подписки владельца или все подписки, если owner пустой
+func (db *PSQL) GetWebhooks(ctx context.Context, owner string) ([]structs.WebhookSubscription, error) {
+	ctx, end := db.observe(ctx, "GetWebhooks")
+	defer end()
+	var rows []webhookSubscriptionRow
+	err := db.conn.SelectContext(ctx, &rows, "SELECT "+webhookSubscriptionColumns+" FROM public.webhook_subscriptions WHERE $1 = '' OR owner = $1 ORDER BY created_at", owner)
+	if err != nil {
+		db.logger.Error(err.Error())
+		return nil, err
+	}
+
+	subs := make([]structs.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subs = append(subs, row.subscription())
+	}
+	return subs, nil
+}
+
+//EnqueueWebhookDeliveries ставит сообщение в очередь доставки всем активным подпискам на его тип.
+//Повторное сообщение с тем же MessageId не дублирует доставки. Возвращает количество новых доставок
+func (db *PSQL) EnqueueWebhookDeliveries(ctx context.Context, messageId string, eventType string, payload []byte, now time.Time) (int64, error) {
+	ctx, end := db.observe(ctx, "EnqueueWebhookDeliveries")
+	defer end()
+	result, err := db.conn.ExecContext(ctx, `INSERT INTO public.webhook_deliveries (subscription_uuid, message_id, event_type, payload, created_at, next_attempt_at)
+		SELECT uuid, $1, $2, $3, $4, $4 FROM public.webhook_subscriptions
+		WHERE active AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))
+		ON CONFLICT (subscription_uuid, message_id) DO NOTHING`,
+		messageId, eventType, payload, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+//webhookAttempt - доставка вместе с адресом и ключом подписки
+type webhookAttempt struct {
+	structs.WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+//ProcessWebhookDeliveries занимает доставки сдвигом next_attempt_at на lease и отправляет без открытой транзакции
+func (db *PSQL) ProcessWebhookDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration, deliver func(delivery structs.WebhookDelivery, url string, secret string) structs.WebhookDelivery) (int, error) {
+	ctx, end := db.observe(ctx, "ProcessWebhookDeliveries")
+	defer end()
+
+	var attempts []webhookAttempt
+	err := db.conn.SelectContext(ctx, &attempts, `WITH due AS (
+			SELECT id FROM public.webhook_deliveries
+			WHERE status = $1 AND next_attempt_at <= $2
+			ORDER BY next_attempt_at LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE public.webhook_deliveries d SET next_attempt_at = $4
+		FROM due, public.webhook_subscriptions s
+		WHERE d.id = due.id AND s.uuid = d.subscription_uuid
+		RETURNING d.id, d.subscription_uuid, d.message_id, d.event_type, d.payload, d.status, d.attempts,
+			d.response_code, d.error, d.created_at, d.next_attempt_at, d.last_attempt_at, s.url, s.secret`,
+		structs.WebhookPending, now, limit, now.Add(lease))
+	if err != nil {
+		return 0, err
+	}
+
+	var recordErr error
+	for _, attempt := range attempts {
+		delivery := deliver(attempt.WebhookDelivery, attempt.URL, attempt.Secret)
+		_, err = db.conn.ExecContext(ctx, "UPDATE public.webhook_deliveries SET status=$1, attempts=$2, response_code=$3, error=$4, next_attempt_at=$5, last_attempt_at=$6 WHERE id = $7",
+			delivery.Status, delivery.Attempts, delivery.ResponseCode, delivery.Error, delivery.NextAttemptAt, delivery.LastAttemptAt, delivery.Id)
+		if err != nil {
+			//остальные доставки уже заняты, поэтому отправляем их, а эта повторится после lease
+			db.logger.Error(fmt.Sprintf("Record webhook delivery %v error %v", delivery.Id, err))
+			if recordErr == nil {
+				recordErr = err
+			}
+		}
+	}
+	return len(attempts), recordErr
+}
+
+//GetWebhookDeliveries возвращает журнал доставок подписки, новые первыми
+func (db *PSQL) GetWebhookDeliveries(ctx context.Context, subscriptionUUID string, limit int) ([]structs.WebhookDelivery, error) {
+	ctx, end := db.observe(ctx, "GetWebhookDeliveries")
+	defer end()
+	var deliveries []structs.WebhookDelivery
+	err := db.conn.SelectContext(ctx, &deliveries, `SELECT id, subscription_uuid, message_id, event_type, payload, status, attempts,
+			response_code, error, created_at, next_attempt_at, last_attempt_at
+		FROM public.webhook_deliveries WHERE subscription_uuid = $1 ORDER BY created_at DESC LIMIT $2`,
+		subscriptionUUID, limit)
+	if err != nil {
+		db.logger.Error(err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func expectOneRow(affected int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("Webhook not exist in DB")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package queue
+
+import (
+	"calendar/internal/structs"
+	"context"
+)
+
+//Handler обрабатывает одно сообщение, полученное из очереди.
+//ctx содержит контекст трассировки, с которым сообщение было отправлено
+type Handler func(ctx context.Context, envelope structs.Envelope) error
+
+//Publisher отправляет сообщения в брокер. Контекст трассировки из ctx передается вместе с сообщением
+type Publisher interface {
+	Publish(ctx context.Context, envelope structs.Envelope) error
+	Close() error
+}
+
+//Subscriber получает сообщения из брокера и передает их в Handler.
+//Receive блокируется до вызова Close
+type Subscriber interface {
+	Receive(handler Handler) error
+	Close() error
+}
+
+type withoutBufferingKey struct{}
+
+//WithoutBuffering - Publish вернет nil, только если брокер принял сообщение
+func WithoutBuffering(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutBufferingKey{}, true)
+}
+
+//BufferingAllowed сообщает, можно ли отложить публикацию с этим ctx
+func BufferingAllowed(ctx context.Context) bool {
+	return ctx.Value(withoutBufferingKey{}) == nil
+}
@@ -0,0 +1,176 @@
+package rabbitmq
+
+import (
+	pb "calendar/internal/proto"
+	"calendar/internal/structs"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/streadway/amqp"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+
+	schemaVersionHeader = "x-schema-version"
+)
+
+//encodeEnvelope кодирует конверт в формат contentType
+func encodeEnvelope(envelope structs.Envelope, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		return json.Marshal(envelope)
+	case ContentTypeProtobuf:
+		pbEnvelope, err := envelopeToProto(envelope)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(pbEnvelope)
+	default:
+		return nil, fmt.Errorf("unsupported content type %v", contentType)
+	}
+}
+
+//decodeDelivery выбирает формат по ContentType, сообщения без него считаются JSON
+func decodeDelivery(d amqp.Delivery) (structs.Envelope, error) {
+	switch d.ContentType {
+	case ContentTypeJSON, "":
+		return unmarshalEnvelope(d.Body)
+	case ContentTypeProtobuf:
+		var pbEnvelope pb.Envelope
+		err := proto.Unmarshal(d.Body, &pbEnvelope)
+		if err != nil {
+			return structs.Envelope{}, err
+		}
+		return envelopeFromProto(&pbEnvelope)
+	default:
+		return structs.Envelope{}, fmt.Errorf("unsupported content type %v", d.ContentType)
+	}
+}
+
+//сообщения старого формата без конверта содержат только structs.Event и считаются напоминаниями
+func unmarshalEnvelope(body []byte) (structs.Envelope, error) {
+	var envelope structs.Envelope
+	err := json.Unmarshal(body, &envelope)
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+	if envelope.Type != "" {
+		return envelope, nil
+	}
+
+	var event structs.Event
+	err = json.Unmarshal(body, &event)
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+	return structs.NewEnvelope(structs.ReminderDue, event), nil
+}
+
+func schemaVersion(headers amqp.Table) int {
+	switch v := headers[schemaVersionHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+func envelopeToProto(envelope structs.Envelope) (*pb.Envelope, error) {
+	occurredAt, err := ptypes.TimestampProto(envelope.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := ptypes.TimestampProto(envelope.Event.DateTime)
+	if err != nil {
+		return nil, err
+	}
+	dtStart, err := ptypes.TimestampProto(envelope.Event.EventDurationStart)
+	if err != nil {
+		return nil, err
+	}
+	dtStop, err := ptypes.TimestampProto(envelope.Event.EventDurationStop)
+	if err != nil {
+		return nil, err
+	}
+
+	pbEnvelope := &pb.Envelope{
+		Type:       envelope.Type,
+		Version:    int32(envelope.Version),
+		MessageId:  envelope.MessageId,
+		OccurredAt: occurredAt,
+		Event: &pb.Event{
+			UUID:            envelope.Event.UUID,
+			Header:          envelope.Event.Header,
+			DateTime:        dt,
+			Description:     envelope.Event.Description,
+			Owner:           envelope.Event.Owner,
+			MailingDuration: envelope.Event.MailingDuration,
+			EventDuration:   &pb.EventDuration{Start: dtStart, Stop: dtStop},
+			Urgent:          envelope.Event.Urgent,
+		},
+	}
+
+	if envelope.Reminder != nil {
+		occurrence, err := ptypes.TimestampProto(envelope.Reminder.Occurrence)
+		if err != nil {
+			return nil, err
+		}
+		pbEnvelope.Reminder = &pb.Reminder{Occurrence: occurrence, Offset: envelope.Reminder.Offset}
+	}
+	return pbEnvelope, nil
+}
+
+func envelopeFromProto(pbEnvelope *pb.Envelope) (structs.Envelope, error) {
+	occurredAt, err := ptypes.Timestamp(pbEnvelope.OccurredAt)
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+
+	event := pbEnvelope.GetEvent()
+	dt, err := ptypes.Timestamp(event.GetDateTime())
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+	dtStart, err := ptypes.Timestamp(event.GetEventDuration().GetStart())
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+	dtStop, err := ptypes.Timestamp(event.GetEventDuration().GetStop())
+	if err != nil {
+		return structs.Envelope{}, err
+	}
+
+	envelope := structs.Envelope{
+		Type:       pbEnvelope.Type,
+		Version:    int(pbEnvelope.Version),
+		MessageId:  pbEnvelope.MessageId,
+		OccurredAt: occurredAt,
+		Event: structs.Event{
+			UUID:               event.GetUUID(),
+			Header:             event.GetHeader(),
+			DateTime:           dt,
+			Description:        event.GetDescription(),
+			Owner:              event.GetOwner(),
+			MailingDuration:    event.GetMailingDuration(),
+			EventDurationStart: dtStart,
+			EventDurationStop:  dtStop,
+			Urgent:             event.GetUrgent(),
+		},
+	}
+
+	if reminder := pbEnvelope.GetReminder(); reminder != nil {
+		occurrence, err := ptypes.Timestamp(reminder.GetOccurrence())
+		if err != nil {
+			return structs.Envelope{}, err
+		}
+		envelope.Reminder = &structs.Reminder{Occurrence: occurrence, Offset: reminder.GetOffset()}
+	}
+	return envelope, nil
+}
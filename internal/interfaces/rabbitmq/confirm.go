@@ -0,0 +1,66 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"github.com/streadway/amqp"
+	"sync"
+	"time"
+)
+
+//confirmedChannel ждет подтверждения каждой публикации, через него уходят повторы и парковка
+type confirmedChannel struct {
+	mu       sync.Mutex
+	ch       *amqp.Channel
+	confirms chan amqp.Confirmation
+	tag      uint64
+	timeout  time.Duration
+}
+
+func newConfirmedChannel(ch *amqp.Channel, timeout time.Duration) (*confirmedChannel, error) {
+	err := ch.Confirm(false)
+	if err != nil {
+		return nil, err
+	}
+	return &confirmedChannel{
+		ch:       ch,
+		confirms: ch.NotifyPublish(make(chan amqp.Confirmation, 100)),
+		timeout:  timeout,
+	}, nil
+}
+
+//Publish отправляет сообщение и ждет подтверждения. Подтверждения приходят по порядку, поэтому публикуем по одному
+func (c *confirmedChannel) Publish(exchange string, key string, msg amqp.Publishing) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.ch.Publish(exchange, key, false, false, msg)
+	if err != nil {
+		return err
+	}
+	c.tag++
+	return waitConfirm(c.confirms, c.tag, c.timeout)
+}
+
+//waitConfirm ждет подтверждения публикации с номером tag не дольше timeout
+func waitConfirm(confirms <-chan amqp.Confirmation, tag uint64, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case confirm, ok := <-confirms:
+			if !ok {
+				return errors.New("channel closed before publish was confirmed")
+			}
+			//подтверждение для предыдущей публикации, по которой уже вышел таймаут
+			if confirm.DeliveryTag < tag {
+				continue
+			}
+			if !confirm.Ack {
+				return fmt.Errorf("broker nacked delivery %v", confirm.DeliveryTag)
+			}
+			return nil
+		case <-deadline:
+			return fmt.Errorf("publish was not confirmed within %v", timeout)
+		}
+	}
+}
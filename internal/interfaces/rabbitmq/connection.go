@@ -0,0 +1,214 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/streadway/amqp"
+	"math/rand"
+	"time"
+)
+
+//connect устанавливает соединение, обьявляет топологию и открывает канал для публикации
+func (r *RabbitMQ) connect() error {
+	conn, err := amqp.DialConfig(r.url, amqp.Config{
+		SASL:      []amqp.Authentication{r.auth},
+		Heartbeat: 10 * time.Second,
+		Locale:    "en_US",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.openChannel(conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	return nil
+}
+
+//openChannel открывает на соединении канал для публикации с подтверждениями и делает их текущими
+func (r *RabbitMQ) openChannel(conn *amqp.Connection) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = r.declareTopology(ch)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+
+	//включаем подтверждения публикации от брокера
+	err = ch.Confirm(false)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 100))
+	channelDown := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.connection = conn
+	r.channel = ch
+	r.channelDown = channelDown
+	r.confirms = confirms
+	//номера доставок на новом канале начинаются заново
+	r.deliveryTag = 0
+	r.connected = true
+
+	//будим всех, кто ждет восстановления соединения
+	close(r.reconnected)
+	r.reconnected = make(chan struct{})
+	return nil
+}
+
+//supervise переподключается после разрыва соединения, а закрытый брокером канал открывает заново на том же соединении
+func (r *RabbitMQ) supervise() {
+	for {
+		conn, ch := r.current()
+		connectionClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		channelClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		select {
+		case <-r.done:
+			return
+		case amqpErr := <-connectionClosed:
+			if r.isClosing() {
+				return
+			}
+			r.markDisconnected()
+			r.logger.Error(fmt.Sprintf("RabbitMQ connection lost: %v", amqpErr))
+
+			if !r.reconnect() {
+				return
+			}
+			r.flushOutageBuffer()
+		case amqpErr := <-channelClosed:
+			if r.isClosing() {
+				return
+			}
+			r.markDisconnected()
+			r.logger.Error(fmt.Sprintf("RabbitMQ publish channel closed: %v", amqpErr))
+
+			//если канал не открывается, соединение тоже неисправно: закрываем его и переподключаемся
+			err := r.openChannel(conn)
+			if err != nil {
+				r.logger.Error(fmt.Sprintf("RabbitMQ channel reopen error %v", err))
+				conn.Close()
+				continue
+			}
+			r.logger.Info("RabbitMQ publish channel restored")
+			r.flushOutageBuffer()
+		}
+	}
+}
+
+func (r *RabbitMQ) isClosing() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+//после восстановления события буферизуются, пока не отправлен буфер
+func (r *RabbitMQ) markDisconnected() {
+	r.mu.Lock()
+	r.connected = false
+	r.mu.Unlock()
+	r.bufferMu.Lock()
+	r.flushing = r.outageMode == OutageModeBuffer
+	r.bufferMu.Unlock()
+}
+
+//reconnect возвращает false, если RabbitMQ был закрыт
+func (r *RabbitMQ) reconnect() bool {
+	delay := r.reconnectDelay
+	for attempt := 1; ; attempt++ {
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		r.logger.Info(fmt.Sprintf("RabbitMQ reconnect attempt %v in %v", attempt, wait))
+
+		select {
+		case <-r.done:
+			return false
+		case <-time.After(wait):
+		}
+
+		err := r.connect()
+		if err == nil {
+			r.logger.Info("RabbitMQ connection restored")
+			return true
+		}
+		r.logger.Error(fmt.Sprintf("RabbitMQ reconnect error %v", err))
+
+		delay *= 2
+		if delay > r.reconnectMaxDelay {
+			delay = r.reconnectMaxDelay
+		}
+	}
+}
+
+//waitConnected ждет восстановления соединения. Возвращает false, если RabbitMQ был закрыт
+func (r *RabbitMQ) waitConnected() bool {
+	for {
+		r.mu.RLock()
+		connected := r.connected && !r.connection.IsClosed()
+		reconnected := r.reconnected
+		r.mu.RUnlock()
+
+		if connected {
+			return true
+		}
+
+		select {
+		case <-r.done:
+			return false
+		case <-reconnected:
+		}
+	}
+}
+
+//IsConnected сообщает, есть ли сейчас рабочее соединение с брокером. Используется в проверках здоровья
+func (r *RabbitMQ) IsConnected() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.connected
+}
+
+//Ready проверяет и канал публикации: брокер может закрыть его при живом соединении
+func (r *RabbitMQ) Ready(ctx context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.connected || r.connection == nil || r.connection.IsClosed() {
+		return ErrNotConnected
+	}
+	select {
+	case amqpErr := <-r.channelDown:
+		if amqpErr != nil {
+			return fmt.Errorf("rabbitmq channel is closed: %v", amqpErr)
+		}
+		return errors.New("rabbitmq channel is closed")
+	default:
+		return nil
+	}
+}
+
+func (r *RabbitMQ) currentConnection() *amqp.Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.connection
+}
+
+func (r *RabbitMQ) current() (*amqp.Connection, *amqp.Channel) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.connection, r.channel
+}
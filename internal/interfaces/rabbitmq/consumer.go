@@ -0,0 +1,130 @@
+package rabbitmq
+
+import (
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/structs"
+	"fmt"
+	"github.com/streadway/amqp"
+	"sync"
+	"time"
+)
+
+//consume раздает сообщения пулу обработчиков. prefetch и workerQueue ограничивают, сколько сообщений ждет в памяти
+func (r *RabbitMQ) consume(handler queue.Handler) error {
+	conn := r.currentConnection()
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	//не запускаем новых потребителей после Close, чтобы он дождался всех начатых
+	r.mu.Lock()
+	select {
+	case <-r.done:
+		r.mu.Unlock()
+		return nil
+	default:
+	}
+	r.consumers.Add(1)
+	r.mu.Unlock()
+	defer r.consumers.Done()
+
+	//отдельный канал, через него же с подтверждениями уходят повторы и парковка
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	republisher, err := newConfirmedChannel(ch, r.confirmTimeout)
+	if err != nil {
+		return err
+	}
+
+	err = ch.Qos(r.prefetch, 0, false)
+	if err != nil {
+		return err
+	}
+
+	tag := fmt.Sprintf("calendar-%v", structs.NewMessageId())
+	msgs, err := ch.Consume(
+		r.queueName, // queue
+		tag,         // consumer
+		false,       // auto-ack
+		false,       // exclusive
+		false,       // no-local
+		false,       // no-wait
+		nil,         // args
+	)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info(fmt.Sprintf("Receiver Waiting for messages with %v workers, prefetch %v", r.workers, r.prefetch))
+
+	jobs := make(chan amqp.Delivery, r.workerQueue)
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range jobs {
+				select {
+				case <-r.done:
+					r.requeue(d)
+				default:
+					r.handleDelivery(republisher, d, handler)
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(jobs)
+
+	for {
+		select {
+		case <-r.done:
+			r.stopConsumer(ch, tag, msgs)
+			return nil
+		case d, ok := <-msgs:
+			if !ok {
+				//канал или соединение закрыты, неподтвержденные сообщения брокер вернет в очередь сам
+				return nil
+			}
+			select {
+			case jobs <- d:
+			case <-r.done:
+				r.requeue(d)
+				r.stopConsumer(ch, tag, msgs)
+				return nil
+			}
+		}
+	}
+}
+
+//stopConsumer отменяет подписку и возвращает в очередь сообщения, которые брокер уже успел прислать
+func (r *RabbitMQ) stopConsumer(ch *amqp.Channel, tag string, msgs <-chan amqp.Delivery) {
+	err := ch.Cancel(tag, false)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Consumer cancel error %v", err))
+		return
+	}
+
+	timeout := time.After(r.shutdownTimeout)
+	for {
+		select {
+		case d, ok := <-msgs:
+			if !ok {
+				return
+			}
+			r.requeue(d)
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func (r *RabbitMQ) requeue(d amqp.Delivery) {
+	err := d.Nack(false, true)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Nack error %v", err))
+	}
+}
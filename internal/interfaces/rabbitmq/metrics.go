@@ -0,0 +1,25 @@
+package rabbitmq
+
+import (
+	"calendar/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	publishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "publish_failures_total",
+		Help:      "Messages that were not published or buffered, by event type.",
+	}, []string{"type"})
+
+	//lag считается от создания события, поэтому включает задержку очередей повторов
+	consumerLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "queue",
+		Name:      "consumer_lag_seconds",
+		Help:      "Time from message creation to the start of its processing, by queue.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"queue"})
+)
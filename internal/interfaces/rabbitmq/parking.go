@@ -0,0 +1,105 @@
+package rabbitmq
+
+import (
+	"calendar/internal/structs"
+	"github.com/streadway/amqp"
+	"time"
+)
+
+type ParkedMessage struct {
+	MessageId   string
+	Timestamp   time.Time
+	RetryCount  int
+	LastError   string
+	ContentType string
+	Body        []byte
+}
+
+//Envelope разбирает тело сообщения в формате из ContentType
+func (m ParkedMessage) Envelope() (structs.Envelope, error) {
+	return decodeDelivery(amqp.Delivery{ContentType: m.ContentType, Body: m.Body})
+}
+
+//ListParked возвращает до limit сообщений из очереди парковки, не удаляя их
+func (r *RabbitMQ) ListParked(limit int) ([]ParkedMessage, error) {
+	ch, err := r.currentConnection().Channel()
+	if err != nil {
+		return nil, err
+	}
+	//неподтвержденные сообщения вернутся в очередь при закрытии канала
+	defer ch.Close()
+
+	parked := make([]ParkedMessage, 0)
+	for len(parked) < limit {
+		d, ok, err := ch.Get(r.parkingQueueName(), false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		lastError, _ := d.Headers[lastErrorHeader].(string)
+		parked = append(parked, ParkedMessage{
+			MessageId:   d.MessageId,
+			Timestamp:   d.Timestamp,
+			RetryCount:  retryCount(d.Headers),
+			LastError:   lastError,
+			ContentType: d.ContentType,
+			Body:        d.Body,
+		})
+	}
+	return parked, nil
+}
+
+//RequeueParked возвращает сообщения с парковки в основную очередь со сброшенным счетчиком повторов
+func (r *RabbitMQ) RequeueParked(limit int) (int, error) {
+	ch, err := r.currentConnection().Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+	//сообщение удаляется из парковки только после того, как брокер подтвердил копию в основной очереди
+	publisher, err := newConfirmedChannel(ch, r.confirmTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for moved < limit {
+		d, ok, err := ch.Get(r.parkingQueueName(), false)
+		if err != nil {
+			return moved, err
+		}
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			if k != retryCountHeader && k != lastErrorHeader {
+				headers[k] = v
+			}
+		}
+
+		err = publisher.Publish("", r.queueName, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Timestamp:    d.Timestamp,
+			Type:         d.Type,
+			Body:         d.Body,
+		})
+		if err != nil {
+			return moved, err
+		}
+
+		err = d.Ack(false)
+		if err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
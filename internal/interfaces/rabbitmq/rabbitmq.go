@@ -1,129 +1,406 @@
 package rabbitmq
 
 import (
+	"calendar/internal/config"
+	"calendar/internal/interfaces/queue"
 	"calendar/internal/structs"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
+	"sync"
+	"time"
 )
 
+const (
+	OutageModeFail   = "fail"
+	OutageModeBuffer = "buffer"
+)
+
+var (
+	ErrNotConnected = errors.New("rabbitmq is not connected")
+	ErrBufferFull   = errors.New("rabbitmq outage buffer is full")
+)
+
+//outgoingMessage - событие и заголовки с контекстом трассировки, с которыми оно уйдет в брокер
+type outgoingMessage struct {
+	body    structs.Envelope
+	headers amqp.Table
+}
+
 type RabbitMQ struct {
-	queue      amqp.Queue
-	channel    amqp.Channel
-	connection amqp.Connection
-	Storage    chan structs.Event
-	logger     *zap.Logger
-	config     map[string]interface{}
+	url          string //без учетных данных, они передаются отдельно в auth
+	auth         *amqp.PlainAuth
+	exchangeName string
+	queueName    string
+	bindings     []string
+	contentType  string
+
+	mu          sync.RWMutex
+	consumers   sync.WaitGroup
+	channel     *amqp.Channel
+	channelDown chan *amqp.Error //закрывается вместе с каналом публикации
+	connection  *amqp.Connection
+	confirms    chan amqp.Confirmation
+	connected   bool
+	reconnected chan struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+
+	confirmTimeout time.Duration
+	publishMu      sync.Mutex
+	deliveryTag    uint64
+
+	outageMode       string
+	outageBufferSize int
+	bufferMu         sync.Mutex
+	outageBuffer     []outgoingMessage
+	flushing         bool //после переподключения буфер еще отправляется, новые события встают за ним
+
+	prefetch        int
+	workers         int
+	workerQueue     int
+	shutdownTimeout time.Duration
+
+	durable           bool
+	maxRetries        int
+	retryDelay        time.Duration
+	reconnectDelay    time.Duration
+	reconnectMaxDelay time.Duration
+	logger            *zap.Logger
 }
 
-func NewRabbitMQ(logger *zap.Logger, config map[string]interface{}) (RabbitMQ, error) {
+func NewRabbitMQ(logger *zap.Logger, config config.RabbitMQConfig) (*RabbitMQ, error) {
 
-	user := config["rabbitmq.user"]
-	password := config["rabbitmq.password"]
-	host := config["rabbitmq.host"]
-	port := config["rabbitmq.port"]
-	vhost := config["rabbitmq.vhost"]
+	if config.ContentType != ContentTypeJSON && config.ContentType != ContentTypeProtobuf {
+		return nil, fmt.Errorf("unsupported rabbitmq content type %v", config.ContentType)
+	}
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	r := &RabbitMQ{
+		url:               fmt.Sprintf("amqp://%v:%v/%v", config.Host, config.Port, config.VHost),
+		auth:              &amqp.PlainAuth{Username: config.User, Password: config.Password.Value()},
+		exchangeName:      config.Exchange,
+		queueName:         config.Queue,
+		bindings:          config.Bindings,
+		contentType:       config.ContentType,
+		reconnected:       make(chan struct{}),
+		done:              make(chan struct{}),
+		confirmTimeout:    config.ConfirmTimeout,
+		outageMode:        config.OutageMode,
+		outageBufferSize:  config.OutageBuffer,
+		prefetch:          config.Prefetch,
+		workers:           workers,
+		workerQueue:       config.WorkerQueue,
+		shutdownTimeout:   config.ShutdownTimeout,
+		durable:           config.Durable,
+		maxRetries:        config.MaxRetries,
+		retryDelay:        config.RetryDelay,
+		reconnectDelay:    config.ReconnectDelay,
+		reconnectMaxDelay: config.ReconnectMaxDelay,
+		logger:            logger,
+	}
 
-	conn, err := amqp.Dial(fmt.Sprintf("amqp://%v:%v@%v:%v/%v", user, password, host, port, vhost))
+	err := r.connect()
 	if err != nil {
-		return RabbitMQ{}, err
+		return nil, err
 	}
-	//defer conn.Close()
 
-	ch, err := conn.Channel()
+	go r.supervise()
+	return r, nil
+}
+
+//задержка входит в имя очереди повтора, потому что аргументы существующей очереди изменить нельзя
+func (r *RabbitMQ) declareTopology(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare(
+		r.exchangeName, // name
+		"topic",        // type
+		r.durable,      // durable
+		false,          // auto-deleted
+		false,          // internal
+		false,          // no-wait
+		nil,            // arguments
+	)
 	if err != nil {
-		return RabbitMQ{}, err
+		return err
 	}
-	//defer ch.Close()
 
-	q, err := ch.QueueDeclare(
-		"calendar", // name
-		false,      // durable
-		false,      // delete when unused
-		false,      // exclusive
-		false,      // no-wait
-		nil,        // arguments
+	_, err = ch.QueueDeclare(
+		r.queueName, // name
+		r.durable,   // durable
+		false,       // delete when unused
+		false,       // exclusive
+		false,       // no-wait
+		nil,         // arguments
 	)
 	if err != nil {
-		return RabbitMQ{}, err
-	}
-	return RabbitMQ{
-		queue:      q,
-		channel:    *ch,
-		connection: *conn,
-		Storage:    make(chan structs.Event),
-		logger:     logger,
-		config:     config,
-	}, nil
+		return err
+	}
+
+	for _, key := range r.bindings {
+		err = ch.QueueBind(r.queueName, key, r.exchangeName, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for attempt := 1; attempt <= r.maxRetries; attempt++ {
+		_, err = ch.QueueDeclare(
+			r.retryQueueName(attempt),
+			r.durable,
+			false,
+			false,
+			false,
+			amqp.Table{
+				"x-message-ttl":             int64(r.retryDelayOf(attempt) / time.Millisecond),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": r.queueName,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = ch.QueueDeclare(r.parkingQueueName(), r.durable, false, false, false, nil)
+	return err
 }
 
+//Close ждет начатую обработку не дольше shutdownTimeout
 func (r *RabbitMQ) Close() error {
-	err := r.channel.Close()
-	if err != nil {
-		return err
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		close(r.done)
+		r.mu.Unlock()
+	})
+
+	finished := make(chan struct{})
+	go func() {
+		r.consumers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(r.shutdownTimeout):
+		r.logger.Error(fmt.Sprintf("Consumers did not finish within %v", r.shutdownTimeout))
+	}
+
+	r.bufferMu.Lock()
+	if len(r.outageBuffer) > 0 {
+		r.logger.Error(fmt.Sprintf("Dropping %v buffered messages on close", len(r.outageBuffer)))
+		r.outageBuffer = nil
+	}
+	r.bufferMu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.connected = false
+	if r.connection == nil || r.connection.IsClosed() {
+		return nil
+	}
+	//закрытие соединения закрывает и все его каналы
+	return r.connection.Close()
+}
+
+//Publish ждет подтверждения брокера. Без соединения буферизует по outageMode, кроме queue.WithoutBuffering
+func (r *RabbitMQ) Publish(ctx context.Context, body structs.Envelope) error {
+	msg := outgoingMessage{body: body, headers: amqp.Table{schemaVersionHeader: int32(body.Version)}}
+	span := r.startPublishSpan(ctx, body.Type, body.MessageId, msg.headers)
+	defer span.End()
+
+	allowBuffer := queue.BufferingAllowed(ctx)
+	buffered, err := r.bufferIfNotReady(msg, allowBuffer)
+	if !buffered {
+		err = r.publish(msg)
+		if err == amqp.ErrClosed && allowBuffer {
+			err = r.bufferPublish(msg)
+		}
 	}
 
-	err = r.connection.Close()
 	if err != nil {
-		return err
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		publishFailures.WithLabelValues(body.Type).Inc()
 	}
-	return nil
+	return err
 }
 
-func (r *RabbitMQ) Publish(body structs.Event) error {
+func (r *RabbitMQ) publish(msg outgoingMessage) error {
+	body := msg.body
 
-	b, err := json.Marshal(body)
+	b, err := encodeEnvelope(body, r.contentType)
 	if err != nil {
 		return err
 	}
 
-	err = r.channel.Publish(
-		"",           // exchange
-		r.queue.Name, // routing key
-		false,        // mandatory
-		false,        // immediate
+	//подтверждения приходят по порядку, поэтому публикуем по одному
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	r.mu.RLock()
+	ch := r.channel
+	confirms := r.confirms
+	r.mu.RUnlock()
+
+	err = ch.Publish(
+		r.exchangeName, // exchange
+		body.Type,      // routing key
+		false,          // mandatory
+		false,          // immediate
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        b,
+			Headers:      msg.headers,
+			ContentType:  r.contentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    body.MessageId,
+			Timestamp:    body.OccurredAt,
+			Type:         body.Type,
+			Body:         b,
 		})
-	r.logger.Info(fmt.Sprintf(" [x] Sent %s", body))
 	if err != nil {
 		return err
 	}
-	return nil
-}
+	r.deliveryTag++
 
-func (r *RabbitMQ) Receive() error {
-	msgs, err := r.channel.Consume(
-		r.queue.Name, // queue
-		"",           // consumer
-		true,         // auto-ack
-		false,        // exclusive
-		false,        // no-local
-		false,        // no-wait
-		nil,          // args
-	)
+	err = waitConfirm(confirms, r.deliveryTag, r.confirmTimeout)
 	if err != nil {
 		return err
 	}
 
-	forever := make(chan bool)
+	r.logger.Info(fmt.Sprintf(" [x] Sent %v", body))
+	return nil
+}
 
-	go func() {
-		for d := range msgs {
-			var jsEvent structs.Event
-			err = json.Unmarshal(d.Body, &jsEvent)
+//проверка и добавление под одной блокировкой, иначе сообщение может попасть в уже отправленный буфер
+func (r *RabbitMQ) bufferIfNotReady(msg outgoingMessage, allowBuffer bool) (bool, error) {
+	r.bufferMu.Lock()
+	defer r.bufferMu.Unlock()
+	if r.IsConnected() && !r.flushing {
+		return false, nil
+	}
+	if !allowBuffer {
+		return true, ErrNotConnected
+	}
+	return true, r.appendToBuffer(msg)
+}
+
+func (r *RabbitMQ) bufferPublish(msg outgoingMessage) error {
+	r.bufferMu.Lock()
+	defer r.bufferMu.Unlock()
+	return r.appendToBuffer(msg)
+}
+
+//appendToBuffer вызывается под bufferMu
+func (r *RabbitMQ) appendToBuffer(msg outgoingMessage) error {
+	if r.outageMode != OutageModeBuffer {
+		return ErrNotConnected
+	}
+	if len(r.outageBuffer) >= r.outageBufferSize {
+		return ErrBufferFull
+	}
+	r.outageBuffer = append(r.outageBuffer, msg)
+	r.logger.Warn(fmt.Sprintf("RabbitMQ is not connected, buffered message (%v in buffer)", len(r.outageBuffer)))
+	return nil
+}
+
+//пока буфер не опустеет, Publish тоже кладет события в него, чтобы не обогнать накопленные
+func (r *RabbitMQ) flushOutageBuffer() {
+	flushed := 0
+flush:
+	for {
+		r.bufferMu.Lock()
+		pending := r.outageBuffer
+		r.outageBuffer = nil
+		if len(pending) == 0 {
+			r.flushing = false
+			r.bufferMu.Unlock()
+			break
+		}
+		r.bufferMu.Unlock()
+
+		for i, msg := range pending {
+			err := r.publish(msg)
 			if err != nil {
-				r.logger.Error(fmt.Sprintf("Unmarshal error %v", err))
-			} else {
-				r.logger.Info(fmt.Sprintf("Publish to chan: %v", jsEvent))
-				r.Storage <- jsEvent
+				r.logger.Error(fmt.Sprintf("Flush of buffered messages stopped: %v", err))
+				r.bufferMu.Lock()
+				r.outageBuffer = append(pending[i:], r.outageBuffer...)
+				r.bufferMu.Unlock()
+
+				if r.Ready(context.Background()) != nil {
+					return
+				}
+				select {
+				case <-r.done:
+					return
+				case <-time.After(r.reconnectDelay):
+				}
+				continue flush
 			}
+			flushed++
 		}
-	}()
+	}
+	if flushed > 0 {
+		r.logger.Info(fmt.Sprintf("Flushed %v buffered messages", flushed))
+	}
+}
 
-	r.logger.Info("Receiver Waiting for messages.")
-	<-forever
-	return nil
+//Receive читает очередь до Close, после разрыва соединения продолжает на новом
+func (r *RabbitMQ) Receive(handler queue.Handler) error {
+	for {
+		err := r.consume(handler)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Consumer error %v", err))
+		}
+
+		select {
+		case <-r.done:
+			return nil
+		case <-time.After(r.reconnectDelay):
+		}
+
+		if !r.waitConnected() {
+			return nil
+		}
+		r.logger.Info("Resuming consumer")
+	}
+}
+
+func (r *RabbitMQ) handleDelivery(ch *confirmedChannel, d amqp.Delivery, handler queue.Handler) {
+	if !d.Timestamp.IsZero() {
+		consumerLag.WithLabelValues(r.queueName).Observe(time.Since(d.Timestamp).Seconds())
+	}
+
+	ctx, span := r.startReceiveSpan(d)
+	defer span.End()
+
+	envelope, err := decodeDelivery(d)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Unmarshal error %v", err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal error")
+		r.park(ch, d, err)
+		return
+	}
+	if version := schemaVersion(d.Headers); version > structs.EnvelopeVersion {
+		r.logger.Warn(fmt.Sprintf("Message %v has newer schema version %v, only known fields are used", d.MessageId, version))
+	}
+
+	r.logger.Info(fmt.Sprintf("Received: %v", envelope))
+	err = handler(ctx, envelope)
+	if err == nil {
+		err = d.Ack(false)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Ack error %v", err))
+		}
+		return
+	}
+
+	r.logger.Error(fmt.Sprintf("Handler error %v", err))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	r.retry(ch, d, err)
 }
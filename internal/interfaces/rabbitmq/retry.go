@@ -0,0 +1,90 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"github.com/streadway/amqp"
+	"time"
+)
+
+const (
+	retryCountHeader = "x-retry-count"
+	lastErrorHeader  = "x-last-error"
+)
+
+//retryDelayOf - задержка перед попыткой attempt, удваивается с каждой попыткой
+func (r *RabbitMQ) retryDelayOf(attempt int) time.Duration {
+	return r.retryDelay << uint(attempt-1)
+}
+
+//retryQueueName - очередь повтора с задержкой в имени: calendar.retry.1.1000ms
+func (r *RabbitMQ) retryQueueName(attempt int) string {
+	return fmt.Sprintf("%v.retry.%v.%vms", r.queueName, attempt, int64(r.retryDelayOf(attempt)/time.Millisecond))
+}
+
+func (r *RabbitMQ) parkingQueueName() string {
+	return r.queueName + ".parking"
+}
+
+//retry отправляет сообщение на повтор или на парковку, если попытки закончились
+func (r *RabbitMQ) retry(ch *confirmedChannel, d amqp.Delivery, cause error) {
+	attempt := retryCount(d.Headers) + 1
+	if attempt > r.maxRetries {
+		r.park(ch, d, cause)
+		return
+	}
+
+	r.logger.Info(fmt.Sprintf("Retry %v of %v for message %v", attempt, r.maxRetries, d.MessageId))
+	r.republish(ch, d, r.retryQueueName(attempt), attempt, cause)
+}
+
+//park отправляет сообщение в очередь парковки, откуда его можно вернуть командой calendar parking
+func (r *RabbitMQ) park(ch *confirmedChannel, d amqp.Delivery, cause error) {
+	r.logger.Error(fmt.Sprintf("Parking message %v: %v", d.MessageId, cause))
+	r.republish(ch, d, r.parkingQueueName(), retryCount(d.Headers), cause)
+}
+
+//оригинал подтверждается только после того, как брокер принял копию
+func (r *RabbitMQ) republish(ch *confirmedChannel, d amqp.Delivery, queueName string, attempt int, cause error) {
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[retryCountHeader] = int32(attempt)
+	headers[lastErrorHeader] = cause.Error()
+
+	err := ch.Publish("", queueName, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    d.MessageId,
+		Timestamp:    d.Timestamp,
+		Type:         d.Type,
+		Body:         d.Body,
+	})
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Republish to %v error %v", queueName, err))
+		err = d.Nack(false, true)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Nack error %v", err))
+		}
+		return
+	}
+
+	err = d.Ack(false)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Ack error %v", err))
+	}
+}
+
+func retryCount(headers amqp.Table) int {
+	switch v := headers[retryCountHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,57 @@
+package rabbitmq
+
+import (
+	"calendar/internal/tracing"
+	"context"
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("rabbitmq")
+
+//headerCarrier передает контекст трассировки в заголовках AMQP (traceparent, tracestate, baggage)
+type headerCarrier amqp.Table
+
+func (c headerCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c headerCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+//startPublishSpan начинает спан отправки и записывает его контекст в headers
+func (r *RabbitMQ) startPublishSpan(ctx context.Context, messageType string, messageId string, headers amqp.Table) trace.Span {
+	ctx, span := tracer.Start(ctx, r.exchangeName+" publish", trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", r.exchangeName),
+			attribute.String("messaging.rabbitmq.destination.routing_key", messageType),
+			attribute.String("messaging.message.id", messageId),
+		))
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	return span
+}
+
+//startReceiveSpan продолжает трассу отправителя из заголовков сообщения
+func (r *RabbitMQ) startReceiveSpan(d amqp.Delivery) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(d.Headers))
+	return tracer.Start(ctx, r.queueName+" process", trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.source.name", r.queueName),
+			attribute.String("messaging.message.id", d.MessageId),
+			attribute.Int("messaging.rabbitmq.retry_count", retryCount(d.Headers)),
+		))
+}
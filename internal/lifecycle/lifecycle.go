@@ -0,0 +1,176 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+//Hook - шаг остановки. ctx истекает вместе с общим таймаутом остановки
+type Hook func(ctx context.Context) error
+
+//Func превращает Close без контекста в Hook
+func Func(close func() error) Hook {
+	return func(ctx context.Context) error {
+		return close()
+	}
+}
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+//Group останавливает сервис по SIGINT/SIGTERM или ошибке горутины: хуки Stop по порядку,
+//ожидание горутин Go, хуки Close в обратном порядке
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	stops  []namedHook
+	closes []namedHook
+}
+
+func New(logger *zap.Logger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &Group{ctx: ctx, cancel: cancel, logger: logger}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Info(fmt.Sprintf("Received %v, shutting down", sig))
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(signals)
+	}()
+
+	return g
+}
+
+//Context отменяется в начале остановки
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+//Go запускает run в горутине, Wait дожидается ее завершения. run должен вернуться после отмены ctx.
+//Ошибка run останавливает весь сервис
+func (g *Group) Go(name string, run func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		err := run(g.ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			g.logger.Error(fmt.Sprintf("%v stopped with error %v", name, err))
+			g.cancel()
+		}
+	}()
+}
+
+//Stop добавляет шаг, который выполняется сразу после отмены контекста, до ожидания горутин
+func (g *Group) Stop(name string, hook Hook) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stops = append(g.stops, namedHook{name: name, hook: hook})
+}
+
+//Close добавляет шаг, который выполняется после завершения горутин. Как и defer, последний добавленный выполняется первым
+func (g *Group) Close(name string, hook Hook) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closes = append(g.closes, namedHook{name: name, hook: hook})
+}
+
+//Shutdown начинает остановку без сигнала
+func (g *Group) Shutdown() {
+	g.cancel()
+}
+
+//Wait блокируется до начала остановки и выполняет ее не дольше timeout.
+//Возвращает ошибку, если какой-то шаг завершился ошибкой или не уложился в таймаут
+func (g *Group) Wait(timeout time.Duration) error {
+	<-g.ctx.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	g.mu.Lock()
+	stops := g.stops
+	closes := g.closes
+	g.mu.Unlock()
+
+	failed := false
+	for _, h := range stops {
+		failed = g.run(ctx, h) || failed
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		g.logger.Error("Shutdown timeout: background tasks are still running")
+		failed = true
+	}
+
+	for i := len(closes) - 1; i >= 0; i-- {
+		failed = g.run(ctx, closes[i]) || failed
+	}
+
+	if failed {
+		return errors.New("shutdown did not complete cleanly")
+	}
+	g.logger.Info("Shutdown complete")
+	return nil
+}
+
+//run выполняет шаг и возвращает true при ошибке
+func (g *Group) run(ctx context.Context, h namedHook) bool {
+	if ctx.Err() != nil {
+		g.logger.Error(fmt.Sprintf("Shutdown timeout: %v skipped", h.name))
+		return true
+	}
+	g.logger.Info(fmt.Sprintf("Stopping %v", h.name))
+
+	//шаг без поддержки контекста не должен задержать остальные дольше таймаута
+	result := make(chan error, 1)
+	go func() {
+		result <- h.hook(ctx)
+	}()
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		g.logger.Error(fmt.Sprintf("Stop of %v error %v", h.name, err))
+		return true
+	}
+	return false
+}
+
+//Sleep ждет d или отмены ctx. Возвращает false, если ctx отменен и циклу пора завершаться
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -1,29 +1,21 @@
 package logger
 
 import (
+	"calendar/internal/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"log"
 )
 
-func GetLogger(vp map[string]interface{}) *zap.Logger {
+//GetLogger возвращает логгер и его уровень, который можно менять без перезапуска
+func GetLogger(config config.LoggerConfig) (*zap.Logger, zap.AtomicLevel) {
 
-	var level zapcore.Level
-	switch vp["logLevel"] {
-	case "DEBUG":
-		level = zapcore.DebugLevel
-	case "INFO":
-		level = zapcore.InfoLevel
-	case "ERROR":
-		level = zapcore.ErrorLevel
-	default:
-		level = zapcore.DebugLevel
-	}
+	level := zap.NewAtomicLevelAt(ParseLevel(config.Level))
 
 	cfg := zap.Config{
 		Encoding:    "json",
-		Level:       zap.NewAtomicLevelAt(level),
-		OutputPaths: vp["outputs"].([]string),
+		Level:       level,
+		OutputPaths: config.Outputs,
 		EncoderConfig: zapcore.EncoderConfig{
 			MessageKey: "message",
 
@@ -42,5 +34,18 @@ func GetLogger(vp map[string]interface{}) *zap.Logger {
 	if err != nil {
 		log.Fatal(err)
 	}
-	return logger
+	return logger, level
+}
+
+func ParseLevel(level string) zapcore.Level {
+	switch level {
+	case "DEBUG":
+		return zapcore.DebugLevel
+	case "INFO":
+		return zapcore.InfoLevel
+	case "ERROR":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.DebugLevel
+	}
 }
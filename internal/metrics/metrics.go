@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"time"
+)
+
+//Namespace - общий префикс метрик сервиса
+const Namespace = "calendar"
+
+var (
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled by the API by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request duration by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+//Server отдает /metrics по HTTP. Через Handle на нем же регистрируются служебные обработчики
+type Server struct {
+	mux    *http.ServeMux
+	server *http.Server
+	logger *zap.Logger
+}
+
+func NewServer(logger *zap.Logger, address string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &Server{
+		mux:    mux,
+		server: &http.Server{Addr: address, Handler: mux},
+		logger: logger,
+	}
+}
+
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+//Run запускает сервер в фоне. Пустой адрес отключает сервер
+func (s *Server) Run() error {
+	if s.server.Addr == "" {
+		return nil
+	}
+
+	go func() {
+		s.logger.Info(fmt.Sprintf("Metrics listening on %v", s.server.Addr))
+		err := s.server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			s.logger.Error(fmt.Sprintf("Metrics server error %v", err))
+		}
+	}()
+
+	return nil
+}
+
+//Shutdown дожидается завершения текущих запросов, но не дольше ctx
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.server.Addr == "" {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
+
+//UnaryServerInterceptor считает запросы и их длительность по методу и коду ответа
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	code := status.Code(err).String()
+	grpcRequests.WithLabelValues(info.FullMethod, code).Inc()
+	grpcDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+	return resp, err
+}
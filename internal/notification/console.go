@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"calendar/internal/i18n"
+	"calendar/internal/structs"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+//ConsoleNotifier пишет оповещения текстом на языке владельца в stdout, stderr или файл
+type ConsoleNotifier struct {
+	out    *consoleOutput
+	bundle *i18n.Bundle
+}
+
+//consoleOutput - открытый вывод, роутеры после перечитывания конфига пишут в него по очереди
+type consoleOutput struct {
+	mu     sync.Mutex
+	name   string
+	w      io.Writer
+	file   *os.File //nil для stdout и stderr
+	closed bool
+}
+
+//NewConsoleNotifier открывает output: "stdout", "stderr" или путь к файлу (дописывается в конец)
+func NewConsoleNotifier(output string, bundle *i18n.Bundle) (*ConsoleNotifier, error) {
+	switch output {
+	case "", "stdout":
+		return &ConsoleNotifier{out: &consoleOutput{name: "stdout", w: os.Stdout}, bundle: bundle}, nil
+	case "stderr":
+		return &ConsoleNotifier{out: &consoleOutput{name: "stderr", w: os.Stderr}, bundle: bundle}, nil
+	default:
+		f, err := os.OpenFile(output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return &ConsoleNotifier{out: &consoleOutput{name: output, w: f, file: f}, bundle: bundle}, nil
+	}
+}
+
+//reuse возвращает нотификатор с новыми локалями в тот же вывод, если output не изменился
+func (c *ConsoleNotifier) reuse(output string, bundle *i18n.Bundle) (*ConsoleNotifier, bool) {
+	if output == "" {
+		output = "stdout"
+	}
+	if c == nil || c.out.name != output {
+		return nil, false
+	}
+	return &ConsoleNotifier{out: c.out, bundle: bundle}, true
+}
+
+func (c *ConsoleNotifier) Name() string {
+	return ChannelConsole
+}
+
+func (c *ConsoleNotifier) Notify(ctx context.Context, recipient Recipient, d structs.Event) error {
+	locale := c.bundle.Locale(recipient.Locale)
+	text, err := locale.Message("reminder_console", struct {
+		Owner       string
+		When        string
+		Header      string
+		Description string
+	}{d.Owner, locale.Format(d.DateTime, recipient.Location, "datetime"), d.Header, d.Description})
+	if err != nil {
+		return err
+	}
+
+	c.out.mu.Lock()
+	defer c.out.mu.Unlock()
+	if c.out.closed {
+		return os.ErrClosed
+	}
+	_, err = fmt.Fprintln(c.out.w, text)
+	return err
+}
+
+//Close закрывает файл вывода после начатых записей. stdout и stderr не закрываются
+func (c *ConsoleNotifier) Close() error {
+	c.out.mu.Lock()
+	defer c.out.mu.Unlock()
+	if c.out.file == nil || c.out.closed {
+		return nil
+	}
+	c.out.closed = true
+	return c.out.file.Close()
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"container/list"
+	"calendar/internal/structs"
+	"context"
+	"sync"
+	"time"
+)
+
+//DedupStore не дает отправить напоминание по каналу дважды. На время отправки id занимается на lease
+type DedupStore interface {
+	//ClaimReminder возвращает structs.ErrReminderSending, если напоминание отправляет другой обработчик
+	ClaimReminder(ctx context.Context, id string, lease time.Duration) (bool, error)
+	//ConfirmReminder отмечает id отправленным на ttl после успешной доставки
+	ConfirmReminder(ctx context.Context, id string, ttl time.Duration) error
+	//ReleaseReminder освобождает id после неудачной доставки, чтобы повтор мог его занять
+	ReleaseReminder(ctx context.Context, id string) error
+}
+
+type dedupEntry struct {
+	id        string
+	expiresAt time.Time
+	sent      bool
+}
+
+//MemoryDedup - LRU в памяти процесса, size должен покрывать число напоминаний за TTL
+type MemoryDedup struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func NewMemoryDedup(size int) *MemoryDedup {
+	return &MemoryDedup{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryDedup) ClaimReminder(ctx context.Context, id string, lease time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if element, ok := m.entries[id]; ok {
+		entry := element.Value.(*dedupEntry)
+		if now.Before(entry.expiresAt) {
+			if !entry.sent {
+				return false, structs.ErrReminderSending
+			}
+			return false, nil
+		}
+	}
+	m.put(id, now.Add(lease), false)
+	return true, nil
+}
+
+//ConfirmReminder продлевает запись до ttl. Запись, вытесненная за время отправки, создается заново
+func (m *MemoryDedup) ConfirmReminder(ctx context.Context, id string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(id, time.Now().Add(ttl), true)
+	return nil
+}
+
+//put создает или обновляет запись и вытесняет самые старые сверх size. Вызывается под mu
+func (m *MemoryDedup) put(id string, expiresAt time.Time, sent bool) {
+	if element, ok := m.entries[id]; ok {
+		entry := element.Value.(*dedupEntry)
+		entry.expiresAt = expiresAt
+		entry.sent = sent
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[id] = m.order.PushFront(&dedupEntry{id: id, expiresAt: expiresAt, sent: sent})
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*dedupEntry).id)
+	}
+}
+
+func (m *MemoryDedup) ReleaseReminder(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[id]; ok {
+		m.order.Remove(element)
+		delete(m.entries, id)
+	}
+	return nil
+}
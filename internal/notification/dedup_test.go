@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupLease(t *testing.T) {
+	ctx := context.Background()
+	dedup := NewMemoryDedup(10)
+
+	claimed, err := dedup.ClaimReminder(ctx, "a", 20*time.Millisecond)
+	if !claimed || err != nil {
+		t.Fatalf("first claim: %v, %v", claimed, err)
+	}
+	//пока lease не истек, напоминание отправляет другой обработчик
+	_, err = dedup.ClaimReminder(ctx, "a", time.Minute)
+	if err != structs.ErrReminderSending {
+		t.Fatalf("claim during lease: %v, want ErrReminderSending", err)
+	}
+
+	//упавший обработчик не подтвердил отправку: после lease напоминание можно занять снова
+	time.Sleep(30 * time.Millisecond)
+	claimed, err = dedup.ClaimReminder(ctx, "a", time.Minute)
+	if !claimed || err != nil {
+		t.Fatalf("claim after lease: %v, %v", claimed, err)
+	}
+
+	dedup.ConfirmReminder(ctx, "a", time.Minute)
+	claimed, err = dedup.ClaimReminder(ctx, "a", time.Minute)
+	if claimed || err != nil {
+		t.Fatalf("claim after confirm: %v, %v", claimed, err)
+	}
+
+	dedup.ClaimReminder(ctx, "b", time.Minute)
+	dedup.ReleaseReminder(ctx, "b")
+	claimed, err = dedup.ClaimReminder(ctx, "b", time.Minute)
+	if !claimed || err != nil {
+		t.Fatalf("claim after release: %v, %v", claimed, err)
+	}
+}
+
+func TestMemoryDedupEviction(t *testing.T) {
+	ctx := context.Background()
+	dedup := NewMemoryDedup(2)
+
+	dedup.ConfirmReminder(ctx, "a", time.Minute)
+	dedup.ConfirmReminder(ctx, "b", time.Minute)
+	//обновление переносит a в начало, поэтому вытесняется b
+	dedup.ConfirmReminder(ctx, "a", time.Minute)
+	dedup.ConfirmReminder(ctx, "c", time.Minute)
+
+	for _, tt := range []struct {
+		id        string
+		wantClaim bool
+	}{{"a", false}, {"c", false}, {"b", true}} {
+		claimed, err := dedup.ClaimReminder(ctx, tt.id, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claimed != tt.wantClaim {
+			t.Errorf("claim %v: %v, want %v", tt.id, claimed, tt.wantClaim)
+		}
+	}
+}
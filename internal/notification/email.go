@@ -0,0 +1,342 @@
+package notification
+
+import (
+	"bytes"
+	"calendar/internal/config"
+	"calendar/internal/i18n"
+	"calendar/internal/structs"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//режимы STARTTLS
+const (
+	StartTLSNone          = "none"
+	StartTLSOpportunistic = "opportunistic"
+	StartTLSRequired      = "required"
+)
+
+type SMTPConfig struct {
+	Host               string
+	Port               string
+	User               string
+	Password           config.Secret
+	From               string
+	StartTLS           string
+	InsecureSkipVerify bool
+	TemplatesDir       string
+	Timeout            time.Duration
+}
+
+//EmailData - данные для шаблонов письма, строковые поля отформатированы для получателя
+type EmailData struct {
+	Owner           string
+	Header          string
+	Description     string
+	DateTime        time.Time
+	Start           time.Time
+	Stop            time.Time
+	Duration        time.Duration
+	MailingDuration int32
+	When            string
+	StartTime       string
+	StopTime        string
+	DurationText    string
+}
+
+//emailTemplates - шаблоны письма одной локали
+type emailTemplates struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+//EmailNotifier отправляет письма multipart (текст, HTML и приглашение .ics) через SMTP
+type EmailNotifier struct {
+	config    SMTPConfig
+	bundle    *i18n.Bundle
+	templates map[string]*emailTemplates
+}
+
+//NewEmailNotifier загружает шаблоны из подкаталогов TemplatesDir, по одному на локаль
+func NewEmailNotifier(config SMTPConfig, bundle *i18n.Bundle) (*EmailNotifier, error) {
+	dirs, err := filepath.Glob(filepath.Join(config.TemplatesDir, "*", "subject.txt.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no email templates found in %v", config.TemplatesDir)
+	}
+
+	templates := make(map[string]*emailTemplates)
+	for _, subjectFile := range dirs {
+		dir := filepath.Dir(subjectFile)
+		t, err := loadEmailTemplates(dir)
+		if err != nil {
+			return nil, err
+		}
+		templates[filepath.Base(dir)] = t
+	}
+
+	return &EmailNotifier{
+		config:    config,
+		bundle:    bundle,
+		templates: templates,
+	}, nil
+}
+
+func loadEmailTemplates(dir string) (*emailTemplates, error) {
+	subject, err := texttemplate.ParseFiles(filepath.Join(dir, "subject.txt.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	text, err := texttemplate.ParseFiles(filepath.Join(dir, "body.txt.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.ParseFiles(filepath.Join(dir, "body.html.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	return &emailTemplates{subject: subject, text: text, html: html}, nil
+}
+
+func (e *EmailNotifier) Name() string {
+	return ChannelEmail
+}
+
+func (e *EmailNotifier) Notify(ctx context.Context, recipient Recipient, d structs.Event) error {
+	if recipient.Email == "" {
+		return &BounceError{errors.New("recipient has no email address")}
+	}
+
+	msg, err := e.buildMessage(recipient, d)
+	if err != nil {
+		return err
+	}
+	return e.send(ctx, recipient.Email, msg)
+}
+
+func (e *EmailNotifier) buildMessage(recipient Recipient, d structs.Event) ([]byte, error) {
+	to := recipient.Email
+	locale := e.bundle.Locale(recipient.Locale)
+	templates, ok := e.templates[locale.Name]
+	if !ok {
+		templates, ok = e.templates[e.bundle.Default().Name]
+		if !ok {
+			return nil, fmt.Errorf("no email templates for locale %v", locale.Name)
+		}
+	}
+
+	data := EmailData{
+		Owner:           d.Owner,
+		Header:          d.Header,
+		Description:     d.Description,
+		DateTime:        d.DateTime,
+		Start:           d.EventDurationStart,
+		Stop:            d.EventDurationStop,
+		Duration:        d.EventDurationStop.Sub(d.EventDurationStart),
+		MailingDuration: d.MailingDuration,
+		When:            locale.Format(d.DateTime, recipient.Location, "datetime"),
+		StartTime:       locale.Format(d.EventDurationStart, recipient.Location, "time"),
+		StopTime:        locale.Format(d.EventDurationStop, recipient.Location, "time"),
+		DurationText:    locale.FormatDuration(d.EventDurationStop.Sub(d.EventDurationStart)),
+	}
+
+	var subject, text, html bytes.Buffer
+	err := templates.subject.Execute(&subject, data)
+	if err != nil {
+		return nil, err
+	}
+	err = templates.text.Execute(&text, data)
+	if err != nil {
+		return nil, err
+	}
+	err = templates.html.Execute(&html, data)
+	if err != nil {
+		return nil, err
+	}
+
+	//multipart/alternative с текстом и HTML, вложенный в multipart/mixed вместе с приглашением
+	var alternativeBody bytes.Buffer
+	alternative := multipart.NewWriter(&alternativeBody)
+	err = writeQuotedPrintable(alternative, "text/plain; charset=UTF-8", text.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	err = writeQuotedPrintable(alternative, "text/html; charset=UTF-8", html.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	err = alternative.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mixed := multipart.NewWriter(&body)
+	alternativePart, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%v", alternative.Boundary())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = alternativePart.Write(alternativeBody.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	icsPart, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {`text/calendar; charset=UTF-8; method=REQUEST; name="invite.ics"`},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {`attachment; filename="invite.ics"`},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = writeBase64(icsPart, buildICS(d, e.config.From, to))
+	if err != nil {
+		return nil, err
+	}
+	err = mixed.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %v\r\n", e.config.From)
+	fmt.Fprintf(&msg, "To: %v\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %v\r\n", mime.QEncoding.Encode("UTF-8", strings.TrimSpace(subject.String())))
+	fmt.Fprintf(&msg, "Date: %v\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%v@%v>\r\n", structs.NewMessageId(), e.config.Host)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/mixed; boundary=%v\r\n\r\n", mixed.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+func writeQuotedPrintable(w *multipart.Writer, contentType string, content []byte) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	_, err = qp.Write(content)
+	if err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+//writeBase64 пишет содержимое в base64 строками по 76 символов
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		_, err := fmt.Fprintf(w, "%v\r\n", encoded[:76])
+		if err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := fmt.Fprintf(w, "%v\r\n", encoded)
+	return err
+}
+
+//send отправляет письмо не дольше Timeout и дедлайна ctx. Отмена ctx обрывает соединение
+func (e *EmailNotifier) send(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(e.config.Host, e.config.Port)
+	dialer := net.Dialer{Timeout: e.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	var deadline time.Time
+	if e.config.Timeout > 0 {
+		deadline = time.Now().Add(e.config.Timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	c, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if e.config.StartTLS != StartTLSNone {
+		ok, _ := c.Extension("STARTTLS")
+		if ok {
+			err = c.StartTLS(&tls.Config{ServerName: e.config.Host, InsecureSkipVerify: e.config.InsecureSkipVerify})
+			if err != nil {
+				return err
+			}
+		} else if e.config.StartTLS == StartTLSRequired {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+	}
+
+	if e.config.User != "" {
+		err = c.Auth(smtp.PlainAuth("", e.config.User, e.config.Password.Value(), e.config.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Mail(e.config.From)
+	if err != nil {
+		return err
+	}
+	err = c.Rcpt(to)
+	if err != nil {
+		//5xx на RCPT - сервер отверг адрес получателя
+		if tpErr, ok := err.(*textproto.Error); ok && tpErr.Code >= 500 {
+			return &BounceError{err}
+		}
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,350 @@
+package notification
+
+import (
+	"bufio"
+	"bytes"
+	"calendar/internal/i18n"
+	"calendar/internal/structs"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+//smtpSession - что stub-сервер получил за одно соединение
+type smtpSession struct {
+	tls  bool
+	auth string
+	from string
+	rcpt string
+	data []byte
+}
+
+//smtpStub - SMTP-сервер в процессе. STARTTLS предлагается, только если задан tlsConfig.
+//RCPT на адрес bounce@... отвергается кодом 550
+type smtpStub struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	sessions  chan smtpSession
+}
+
+func newSMTPStub(t *testing.T, withTLS bool) *smtpStub {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &smtpStub{listener: listener, sessions: make(chan smtpSession, 1)}
+	if withTLS {
+		s.tlsConfig = &tls.Config{Certificates: []tls.Certificate{selfSignedCert(t)}}
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStub) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	var session smtpSession
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 stub ready")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if s.tlsConfig != nil && !session.tls {
+				text.PrintfLine("250-stub\r\n250-STARTTLS\r\n250 AUTH PLAIN")
+			} else {
+				text.PrintfLine("250-stub\r\n250 AUTH PLAIN")
+			}
+		case "STARTTLS":
+			text.PrintfLine("220 go ahead")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			session.tls = true
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(encoded)
+			session.auth = string(decoded)
+			text.PrintfLine("235 authenticated")
+		case "MAIL":
+			session.from = arg
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			if strings.Contains(arg, "bounce@") {
+				text.PrintfLine("550 no such mailbox")
+				continue
+			}
+			session.rcpt = arg
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 send data")
+			session.data, err = io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			text.PrintfLine("250 queued")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			s.sessions <- session
+			return
+		default:
+			text.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+func selfSignedCert(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func newTestEmailNotifier(t *testing.T, stub *smtpStub, startTLS string, user string) *EmailNotifier {
+	bundle, err := i18n.LoadBundle("../../configs/locales", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := NewEmailNotifier(SMTPConfig{
+		Host:               "127.0.0.1",
+		Port:               stub.port(),
+		User:               user,
+		Password:           "smtp-password",
+		From:               "calendar@example.com",
+		StartTLS:           startTLS,
+		InsecureSkipVerify: true,
+		TemplatesDir:       "../../configs/templates/email",
+		Timeout:            5 * time.Second,
+	}, bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func testEvent() structs.Event {
+	start := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	return structs.Event{UUID: "event-uuid", Header: "Планёрка", Description: "Обсуждение релиза", Owner: "owner",
+		DateTime: start, EventDurationStart: start, EventDurationStop: start.Add(time.Hour)}
+}
+
+func TestEmailStartTLS(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		serverTLS bool
+		wantTLS   bool
+		wantErr   string
+	}{
+		{"none ignores offered tls", StartTLSNone, true, false, ""},
+		{"opportunistic upgrades", StartTLSOpportunistic, true, true, ""},
+		{"opportunistic falls back to plain", StartTLSOpportunistic, false, false, ""},
+		{"required upgrades", StartTLSRequired, true, true, ""},
+		{"required fails without tls", StartTLSRequired, false, false, "does not support STARTTLS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newSMTPStub(t, tt.serverTLS)
+			e := newTestEmailNotifier(t, stub, tt.mode, "")
+
+			err := e.Notify(context.Background(), Recipient{Email: "owner@example.com", Locale: "en", Location: time.UTC}, testEvent())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			session := <-stub.sessions
+			if session.tls != tt.wantTLS {
+				t.Errorf("tls %v, want %v", session.tls, tt.wantTLS)
+			}
+			if session.rcpt != "TO:<owner@example.com>" {
+				t.Errorf("rcpt %v", session.rcpt)
+			}
+		})
+	}
+}
+
+func TestEmailAuth(t *testing.T) {
+	stub := newSMTPStub(t, true)
+	e := newTestEmailNotifier(t, stub, StartTLSRequired, "calendar")
+
+	err := e.Notify(context.Background(), Recipient{Email: "owner@example.com", Locale: "en", Location: time.UTC}, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := <-stub.sessions
+	if session.auth != "\x00calendar\x00smtp-password" {
+		t.Errorf("auth %q", session.auth)
+	}
+}
+
+func TestEmailBounce(t *testing.T) {
+	stub := newSMTPStub(t, false)
+	e := newTestEmailNotifier(t, stub, StartTLSNone, "")
+
+	err := e.Notify(context.Background(), Recipient{Email: "bounce@example.com", Locale: "en", Location: time.UTC}, testEvent())
+	var bounce *BounceError
+	if !errors.As(err, &bounce) {
+		t.Fatalf("got %v, want BounceError", err)
+	}
+	err = e.Notify(context.Background(), Recipient{Locale: "en", Location: time.UTC}, testEvent())
+	if !errors.As(err, &bounce) {
+		t.Fatalf("no address: got %v, want BounceError", err)
+	}
+}
+
+//TestEmailMessage разбирает письмо: multipart/mixed из multipart/alternative (текст и HTML в quoted-printable)
+//и приглашения .ics в base64
+func TestEmailMessage(t *testing.T) {
+	stub := newSMTPStub(t, false)
+	e := newTestEmailNotifier(t, stub, StartTLSNone, "")
+	event := testEvent()
+
+	err := e.Notify(context.Background(), Recipient{Email: "owner@example.com", Locale: "ru", Location: time.UTC}, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := <-stub.sessions
+
+	msg, err := mail.ReadMessage(bytes.NewReader(session.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || !strings.Contains(subject, event.Header) {
+		t.Errorf("subject %q, error %v", subject, err)
+	}
+
+	mixed := multipartReader(t, msg.Header.Get("Content-Type"), "multipart/mixed", msg.Body)
+	alternativePart, err := mixed.NextRawPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alternative := multipartReader(t, alternativePart.Header.Get("Content-Type"), "multipart/alternative", alternativePart)
+	for _, contentType := range []string{"text/plain", "text/html"} {
+		part, err := alternative.NextRawPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); got != contentType {
+			t.Fatalf("alternative part %v, want %v", got, contentType)
+		}
+		if encoding := part.Header.Get("Content-Transfer-Encoding"); encoding != "quoted-printable" {
+			t.Errorf("%v encoding %v", contentType, encoding)
+		}
+		raw, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//кириллица в quoted-printable кодируется, в сыром виде ее быть не должно
+		if bytes.Contains(raw, []byte(event.Header)) {
+			t.Errorf("%v part is not encoded:\n%s", contentType, raw)
+		}
+		decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(decoded, []byte(event.Header)) || !bytes.Contains(decoded, []byte(event.Description)) {
+			t.Errorf("%v part misses the event:\n%s", contentType, decoded)
+		}
+	}
+	if _, err = alternative.NextRawPart(); err != io.EOF {
+		t.Errorf("extra alternative part: %v", err)
+	}
+
+	ics, err := mixed.NextRawPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, params, _ := mime.ParseMediaType(ics.Header.Get("Content-Type")); got != "text/calendar" || params["method"] != "REQUEST" {
+		t.Errorf("attachment %v %v", got, params)
+	}
+	if encoding := ics.Header.Get("Content-Transfer-Encoding"); encoding != "base64" {
+		t.Errorf("attachment encoding %v", encoding)
+	}
+	scanner := bufio.NewScanner(ics)
+	var encoded strings.Builder
+	for scanner.Scan() {
+		if len(scanner.Text()) > 76 {
+			t.Errorf("base64 line longer than 76: %v", len(scanner.Text()))
+		}
+		encoded.WriteString(scanner.Text())
+	}
+	calendar, err := base64.StdEncoding.DecodeString(encoded.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"BEGIN:VCALENDAR", "METHOD:REQUEST", "UID:event-uuid@calendar", "DTSTART:20300102T100000Z", "END:VCALENDAR"} {
+		if !bytes.Contains(calendar, []byte(want)) {
+			t.Errorf("invite misses %v:\n%s", want, calendar)
+		}
+	}
+	if _, err = mixed.NextRawPart(); err != io.EOF {
+		t.Errorf("extra mixed part: %v", err)
+	}
+}
+
+func multipartReader(t *testing.T, header string, want string, body io.Reader) *multipart.Reader {
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != want {
+		t.Fatalf("content type %v, want %v", mediaType, want)
+	}
+	return multipart.NewReader(body, params["boundary"])
+}
@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"calendar/internal/structs"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+//buildICS собирает приглашение iCalendar (RFC 5545) для события
+func buildICS(d structs.Event, organizer string, attendee string) []byte {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//IQXI//calendar//RU",
+		"METHOD:REQUEST",
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%v@calendar", d.UUID),
+		"DTSTAMP:" + time.Now().UTC().Format(icsTimeFormat),
+		"DTSTART:" + d.EventDurationStart.UTC().Format(icsTimeFormat),
+		"DTEND:" + d.EventDurationStop.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + icsEscape(d.Header),
+		"DESCRIPTION:" + icsEscape(d.Description),
+		"ORGANIZER:mailto:" + organizer,
+		"ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE:mailto:" + attendee,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(icsFold(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+//icsFold переносит строки длиннее 75 байт, не разрывая символы UTF-8
+func icsFold(line string) string {
+	var b strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > 75 {
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length += size
+	}
+	return b.String()
+}
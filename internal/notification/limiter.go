@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"sync"
+)
+
+//limiter ограничивает число одновременных доставок по каналу
+type limiter struct {
+	mu      sync.Mutex
+	limits  map[string]int
+	active  map[string]int
+	changed chan struct{} //закрывается, когда освободилось место или изменились лимиты
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		limits:  make(map[string]int),
+		active:  make(map[string]int),
+		changed: make(chan struct{}),
+	}
+}
+
+//set заменяет лимиты. Каналы без положительного лимита не ограничены
+func (l *limiter) set(limits map[string]int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = make(map[string]int)
+	for channel, limit := range limits {
+		if limit > 0 {
+			l.limits[channel] = limit
+		}
+	}
+	l.wake()
+}
+
+//acquire ждет свободного места в канале до отмены ctx. release нужно вызвать после доставки
+func (l *limiter) acquire(ctx context.Context, channel string) (release func(), err error) {
+	for {
+		l.mu.Lock()
+		limit, limited := l.limits[channel]
+		if !limited || l.active[channel] < limit {
+			l.active[channel]++
+			l.mu.Unlock()
+			return func() { l.release(channel) }, nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (l *limiter) release(channel string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active[channel]--
+	l.wake()
+}
+
+//wake будит всех, кто ждет места. Вызывается под mu
+func (l *limiter) wake() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter()
+	l.set(map[string]int{ChannelEmail: 1, ChannelWebhook: 0})
+
+	release, err := l.acquire(context.Background(), ChannelEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//каналы без положительного лимита не ограничены
+	for i := 0; i < 3; i++ {
+		_, err = l.acquire(context.Background(), ChannelWebhook)
+		if err != nil {
+			t.Fatalf("unlimited channel: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, ChannelEmail)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("acquire over limit: %v, want deadline exceeded", err)
+	}
+
+	//освобождение места будит ожидающего
+	acquired := make(chan error, 1)
+	go func() {
+		_, err := l.acquire(context.Background(), ChannelEmail)
+		acquired <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	release()
+	select {
+	case err = <-acquired:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken by release")
+	}
+
+	//увеличение лимита тоже будит ожидающего
+	go func() {
+		_, err := l.acquire(context.Background(), ChannelEmail)
+		acquired <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	l.set(map[string]int{ChannelEmail: 2})
+	select {
+	case err = <-acquired:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken by a new limit")
+	}
+}
@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"calendar/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	deliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "notification",
+		Name:      "deliveries_total",
+		Help:      "Reminder delivery attempts by channel and status. Deferred reminders have an empty channel.",
+	}, []string{"channel", "status"})
+
+	//от минут до часов: отложенные из-за тихих часов напоминания доставляются с большой задержкой
+	deliveryLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "notification",
+		Name:      "delivery_latency_seconds",
+		Help:      "Time from the reminder notify_at to a successful delivery, by channel.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+	}, []string{"channel"})
+)
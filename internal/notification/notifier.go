@@ -0,0 +1,409 @@
+package notification
+
+import (
+	"calendar/internal/structs"
+	"calendar/internal/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+//имена каналов оповещения, используются в конфиге и настройках владельцев
+const (
+	ChannelConsole = "console"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+//Recipient - куда доставлять оповещения владельцу события
+type Recipient struct {
+	Owner      string
+	Email      string
+	WebhookURL string
+	Locale     string
+	Location   *time.Location
+}
+
+//Notifier доставляет оповещение о событии по одному каналу. Отмена ctx прерывает доставку
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, recipient Recipient, event structs.Event) error
+}
+
+//BounceError - получатель отверг оповещение, повтор не поможет
+type BounceError struct {
+	Err error
+}
+
+func (e *BounceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BounceError) Unwrap() error {
+	return e.Err
+}
+
+//DeliveryLog сохраняет результат доставки напоминания по каждому каналу
+type DeliveryLog interface {
+	RecordReminderDelivery(ctx context.Context, delivery structs.ReminderDelivery) error
+}
+
+//Preferences - настройки оповещений владельца. Пустые поля берутся из настроек по умолчанию
+type Preferences = structs.NotificationPreferences
+
+//PreferenceSource возвращает настройки владельца. ok=false, если настроек нет
+type PreferenceSource interface {
+	Preferences(ctx context.Context, owner string) (prefs Preferences, ok bool, err error)
+}
+
+//Router рассылает оповещение по каналам владельца или по каналам по умолчанию
+type Router struct {
+	notifiers   map[string]Notifier
+	defaults    Preferences
+	location    *time.Location //часовой пояс из defaults
+	preferences PreferenceSource
+	schedule    ScheduleStore
+	escalation  []string
+	deliveries  DeliveryLog
+	dedup       DedupStore
+	dedupTTL    time.Duration
+	dedupLease  time.Duration
+	limiter     *limiter
+	logger      *zap.Logger
+}
+
+//defaults - настройки для владельцев без собственных
+func NewRouter(logger *zap.Logger, preferences PreferenceSource, defaults Preferences, notifiers ...Notifier) (*Router, error) {
+	location, err := time.LoadLocation(defaults.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("default time zone %v: %v", defaults.TimeZone, err)
+	}
+
+	r := &Router{
+		notifiers:   make(map[string]Notifier),
+		limiter:     newLimiter(),
+		defaults:    defaults,
+		location:    location,
+		preferences: preferences,
+		logger:      logger,
+	}
+	for _, n := range notifiers {
+		r.notifiers[n.Name()] = n
+	}
+	return r, nil
+}
+
+//WithSchedule включает тихие часы и суточный лимит. escalation - каналы, которые добавляются для срочных напоминаний
+func (r *Router) WithSchedule(schedule ScheduleStore, escalation []string) *Router {
+	r.schedule = schedule
+	r.escalation = escalation
+	return r
+}
+
+//WithDeliveryLog включает журнал доставок
+func (r *Router) WithDeliveryLog(deliveries DeliveryLog) *Router {
+	r.deliveries = deliveries
+	return r
+}
+
+//без WithDedup повтор из очереди после ошибки одного канала снова отправит напоминание по остальным
+func (r *Router) WithDedup(dedup DedupStore, ttl time.Duration, lease time.Duration) *Router {
+	r.dedup = dedup
+	r.dedupTTL = ttl
+	r.dedupLease = lease
+	return r
+}
+
+//WithConcurrency не дает медленному каналу занять все обработчики
+func (r *Router) WithConcurrency(limits map[string]int) *Router {
+	r.limiter.set(limits)
+	return r
+}
+
+var tracer = tracing.Tracer("notification")
+
+//Notify доставляет напоминание во все каналы владельца или откладывает его. BounceError не возвращается
+func (r *Router) Notify(ctx context.Context, event structs.Event, reminder structs.Reminder) error {
+	prefs, err := r.resolve(ctx, event.Owner)
+	if err != nil {
+		return err
+	}
+
+	location, err := r.ownerLocation(event.Owner, prefs.TimeZone)
+	if err != nil {
+		return err
+	}
+	recipient := Recipient{
+		Owner:      event.Owner,
+		Email:      prefs.Email,
+		WebhookURL: prefs.WebhookURL,
+		Locale:     prefs.Locale,
+		Location:   location,
+	}
+	channels := prefs.Channels
+	scheduled := !event.Urgent && r.schedule != nil
+	local := time.Now().In(recipient.Location)
+
+	if event.Urgent {
+		channels = mergeChannels(channels, r.escalation)
+	} else if scheduled {
+		until, quiet, err := quietUntil(local, prefs.QuietHoursStart, prefs.QuietHoursEnd)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Quiet hours of %v are ignored: %v", event.Owner, err))
+		}
+		if quiet {
+			return r.deferReminder(ctx, event, reminder, until, "quiet hours")
+		}
+	}
+
+	//сначала занимаем каналы: по уже доставленным ничего не отправляется и лимит не тратится
+	pending := make([]channelDelivery, 0, len(channels))
+	alreadySent := false
+	failed := make([]string, 0)
+	for _, channel := range channels {
+		notifier, ok := r.notifiers[channel]
+		if !ok {
+			r.logger.Error(fmt.Sprintf("Notification channel %v is not configured", channel))
+			continue
+		}
+
+		reminderId := structs.ReminderId(event.UUID, reminder, channel)
+		if r.dedup != nil {
+			claimed, err := r.dedup.ClaimReminder(ctx, reminderId, r.dedupLease)
+			if err != nil {
+				r.logger.Error(fmt.Sprintf("Dedup of %v error %v", reminderId, err))
+				failed = append(failed, fmt.Sprintf("%v: %v", channel, err))
+				continue
+			}
+			if !claimed {
+				r.logger.Info(fmt.Sprintf("Reminder %v for %v via %v already sent", reminderId, event.Owner, channel))
+				alreadySent = true
+				continue
+			}
+		}
+		pending = append(pending, channelDelivery{channel: channel, notifier: notifier, reminderId: reminderId})
+	}
+
+	//суточный лимит считает напоминание один раз: если часть каналов уже доставлена, это повтор из очереди
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	reserved := false
+	if scheduled && prefs.DailyCap > 0 && len(pending) > 0 && !alreadySent {
+		ok, err := r.schedule.ReserveDailyNotification(ctx, event.Owner, day, prefs.DailyCap)
+		if err != nil {
+			r.releaseAll(ctx, pending)
+			return err
+		}
+		if !ok {
+			r.releaseAll(ctx, pending)
+			until := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, recipient.Location)
+			return r.deferReminder(ctx, event, reminder, until, fmt.Sprintf("daily cap %v", prefs.DailyCap))
+		}
+		reserved = true
+	}
+
+	delivered := false
+	for _, d := range pending {
+		err = r.send(ctx, d.channel, d.notifier, recipient, event)
+		status := structs.ReminderSent
+		if err == nil {
+			delivered = true
+			r.confirm(ctx, d.reminderId)
+			deliveryLatency.WithLabelValues(d.channel).Observe(time.Since(reminder.NotifyAt()).Seconds())
+		} else {
+			r.logger.Error(fmt.Sprintf("Notify %v via %v error %v", event.Owner, d.channel, err))
+			var bounce *BounceError
+			if errors.As(err, &bounce) {
+				delivered = true
+				status = structs.ReminderBounced
+				r.confirm(ctx, d.reminderId)
+			} else {
+				status = structs.ReminderFailed
+				failed = append(failed, fmt.Sprintf("%v: %v", d.channel, err))
+				r.release(ctx, d.reminderId)
+			}
+		}
+		r.record(ctx, event, d.reminderId, d.channel, status, err, nil)
+	}
+
+	//ничего не доставлено: повтор из очереди зарезервирует лимит заново
+	if reserved && !delivered {
+		err = r.schedule.ReleaseDailyNotification(ctx, event.Owner, day)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Release daily notification of %v error %v", event.Owner, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+//channelDelivery - канал, занятый для доставки напоминания
+type channelDelivery struct {
+	channel    string
+	notifier   Notifier
+	reminderId string
+}
+
+//ожидание лимита входит в спан, чтобы было видно, где напоминание задержалось
+func (r *Router) send(ctx context.Context, channel string, notifier Notifier, recipient Recipient, event structs.Event) error {
+	ctx, span := tracer.Start(ctx, "notify "+channel,
+		trace.WithAttributes(tracing.EventUUID.String(event.UUID), attribute.String("notification.channel", channel)))
+	defer span.End()
+
+	release, err := r.limiter.acquire(ctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	defer release()
+
+	err = notifier.Notify(ctx, recipient, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+//отложенное на время после начала события напоминание бесполезно, оно записывается как dropped
+func (r *Router) deferReminder(ctx context.Context, event structs.Event, reminder structs.Reminder, until time.Time, reason string) error {
+	if !until.Before(reminder.Occurrence) {
+		r.logger.Info(fmt.Sprintf("Reminder %v for %v dropped: %v until %v, event starts at %v", event.UUID, event.Owner, reason, until, reminder.Occurrence))
+		r.record(ctx, event, structs.ReminderId(event.UUID, reminder, ""), "", structs.ReminderDropped,
+			fmt.Errorf("%v until %v, after event start", reason, until), nil)
+		return nil
+	}
+
+	r.logger.Info(fmt.Sprintf("Reminder %v for %v deferred until %v: %v", event.UUID, event.Owner, until, reason))
+	err := r.schedule.DeferReminder(ctx, event, reminder, until, reason)
+	if err != nil {
+		return err
+	}
+	r.record(ctx, event, structs.ReminderId(event.UUID, reminder, ""), "", structs.ReminderDeferred, errors.New(reason), &until)
+	return nil
+}
+
+func (r *Router) releaseAll(ctx context.Context, deliveries []channelDelivery) {
+	for _, d := range deliveries {
+		r.release(ctx, d.reminderId)
+	}
+}
+
+//при ошибке confirm запись истечет через dedupLease, и повтор может отправить напоминание еще раз
+func (r *Router) confirm(ctx context.Context, reminderId string) {
+	if r.dedup == nil {
+		return
+	}
+	err := r.dedup.ConfirmReminder(ctx, reminderId, r.dedupTTL)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Dedup confirm of %v error %v", reminderId, err))
+	}
+}
+
+//release освобождает напоминание после неудачной доставки, чтобы повтор из очереди его отправил
+func (r *Router) release(ctx context.Context, reminderId string) {
+	if r.dedup == nil {
+		return
+	}
+	err := r.dedup.ReleaseReminder(ctx, reminderId)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Dedup release of %v error %v", reminderId, err))
+	}
+}
+
+//record пишет результат в журнал доставок. Ошибка журнала не должна вызывать повторную рассылку, поэтому только логируется
+func (r *Router) record(ctx context.Context, event structs.Event, reminderId string, channel string, status string, err error, deferredTo *time.Time) {
+	deliveries.WithLabelValues(channel, status).Inc()
+	if r.deliveries == nil {
+		return
+	}
+
+	delivery := structs.ReminderDelivery{
+		ReminderId:  reminderId,
+		EventUUID:   event.UUID,
+		Owner:       event.Owner,
+		Channel:     channel,
+		Status:      status,
+		AttemptedAt: time.Now().UTC(),
+		DeferredTo:  deferredTo,
+	}
+	if err != nil {
+		text := err.Error()
+		delivery.Error = &text
+	}
+
+	err = r.deliveries.RecordReminderDelivery(ctx, delivery)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Record delivery of %v via %v error %v", event.UUID, channel, err))
+	}
+}
+
+//resolve накладывает настройки владельца на настройки по умолчанию
+func (r *Router) resolve(ctx context.Context, owner string) (Preferences, error) {
+	prefs := r.defaults
+	own, ok, err := r.preferences.Preferences(ctx, owner)
+	if err != nil || !ok {
+		return prefs, err
+	}
+
+	prefs.Email = own.Email
+	prefs.WebhookURL = own.WebhookURL
+	if len(own.Channels) > 0 {
+		prefs.Channels = own.Channels
+	}
+	if own.Locale != "" {
+		prefs.Locale = own.Locale
+	}
+	if own.TimeZone != "" {
+		prefs.TimeZone = own.TimeZone
+	}
+	if len(own.ReminderOffsets) > 0 {
+		prefs.ReminderOffsets = own.ReminderOffsets
+	}
+	if own.QuietHoursStart != "" || own.QuietHoursEnd != "" {
+		prefs.QuietHoursStart = own.QuietHoursStart
+		prefs.QuietHoursEnd = own.QuietHoursEnd
+	}
+	if own.DailyCap > 0 {
+		prefs.DailyCap = own.DailyCap
+	}
+	return prefs, nil
+}
+
+//пояса проверяются при сохранении, поэтому ошибка здесь - сбой окружения, и напоминание возвращается в очередь
+func (r *Router) ownerLocation(owner string, timeZone string) (*time.Location, error) {
+	if timeZone == r.defaults.TimeZone {
+		return r.location, nil
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %v of %v: %v", timeZone, owner, err)
+	}
+	return loc, nil
+}
+
+func mergeChannels(channels []string, extra []string) []string {
+	merged := append([]string{}, channels...)
+	for _, channel := range extra {
+		found := false
+		for _, c := range merged {
+			if c == channel {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, channel)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,166 @@
+package notification
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type fakeNotifier struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (n *fakeNotifier) Name() string {
+	return n.name
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, recipient Recipient, event structs.Event) error {
+	n.calls++
+	return n.err
+}
+
+type noPreferences struct{}
+
+func (noPreferences) Preferences(ctx context.Context, owner string) (Preferences, bool, error) {
+	return Preferences{}, false, nil
+}
+
+//fakeSchedule считает оповещения владельца без учета дня, в тесте он один
+type fakeSchedule struct {
+	reserved int32
+	deferred []time.Time
+}
+
+func (s *fakeSchedule) ReserveDailyNotification(ctx context.Context, owner string, day time.Time, limit int32) (bool, error) {
+	if s.reserved >= limit {
+		return false, nil
+	}
+	s.reserved++
+	return true, nil
+}
+
+func (s *fakeSchedule) ReleaseDailyNotification(ctx context.Context, owner string, day time.Time) error {
+	s.reserved--
+	return nil
+}
+
+func (s *fakeSchedule) DeferReminder(ctx context.Context, event structs.Event, reminder structs.Reminder, until time.Time, reason string) error {
+	s.deferred = append(s.deferred, until)
+	return nil
+}
+
+func TestRouterNotify(t *testing.T) {
+	failure := errors.New("smtp is down")
+	bounce := fmt.Errorf("send: %w", &BounceError{errors.New("no such mailbox")})
+
+	tests := []struct {
+		name     string
+		urgent   bool
+		quiet    bool
+		dailyCap int32
+		reserved int32            //оповещений за сегодня до вызова
+		sent     []string         //каналы, по которым напоминание уже доставлено
+		errs     map[string]error //ошибки каналов
+		calls    []string         //каналы, в которые ушла доставка
+		deferred bool
+		wantErr  bool
+		confirm  []string //каналы, отмеченные доставленными
+		release  []string //каналы, освобожденные для повтора
+		wantCap  int32    //оповещений за сегодня после вызова
+	}{
+		{name: "owner channels", calls: []string{ChannelConsole, ChannelEmail}, confirm: []string{ChannelConsole, ChannelEmail}},
+		{name: "quiet hours defer", quiet: true, deferred: true, release: []string{ChannelConsole, ChannelEmail}},
+		{name: "urgent escalates through quiet hours", urgent: true, quiet: true,
+			calls: []string{ChannelConsole, ChannelEmail, ChannelWebhook}, confirm: []string{ChannelConsole, ChannelEmail, ChannelWebhook}},
+		{name: "urgent ignores daily cap", urgent: true, dailyCap: 1, reserved: 1, wantCap: 1,
+			calls: []string{ChannelConsole, ChannelEmail, ChannelWebhook}, confirm: []string{ChannelConsole, ChannelEmail, ChannelWebhook}},
+		{name: "daily cap reserved once", dailyCap: 2, wantCap: 1,
+			calls: []string{ChannelConsole, ChannelEmail}, confirm: []string{ChannelConsole, ChannelEmail}},
+		{name: "daily cap defers and releases claims", dailyCap: 1, reserved: 1, wantCap: 1, deferred: true,
+			release: []string{ChannelConsole, ChannelEmail}},
+		{name: "retry after partial delivery skips cap", dailyCap: 1, reserved: 1, wantCap: 1, sent: []string{ChannelConsole},
+			calls: []string{ChannelEmail}, confirm: []string{ChannelConsole, ChannelEmail}},
+		{name: "already sent reserves nothing", dailyCap: 1, sent: []string{ChannelConsole, ChannelEmail},
+			confirm: []string{ChannelConsole, ChannelEmail}},
+		{name: "failed channel released", dailyCap: 2, wantCap: 1, errs: map[string]error{ChannelEmail: failure}, wantErr: true,
+			calls: []string{ChannelConsole, ChannelEmail}, confirm: []string{ChannelConsole}, release: []string{ChannelEmail}},
+		{name: "nothing delivered returns cap", dailyCap: 2, errs: map[string]error{ChannelConsole: failure, ChannelEmail: failure}, wantErr: true,
+			calls: []string{ChannelConsole, ChannelEmail}, release: []string{ChannelConsole, ChannelEmail}},
+		{name: "bounce is not retried", dailyCap: 2, wantCap: 1, errs: map[string]error{ChannelEmail: bounce},
+			calls: []string{ChannelConsole, ChannelEmail}, confirm: []string{ChannelConsole, ChannelEmail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			notifiers := map[string]*fakeNotifier{}
+			for _, channel := range []string{ChannelConsole, ChannelEmail, ChannelWebhook} {
+				notifiers[channel] = &fakeNotifier{name: channel, err: tt.errs[channel]}
+			}
+
+			defaults := Preferences{Channels: []string{ChannelConsole, ChannelEmail}, TimeZone: "UTC", DailyCap: tt.dailyCap}
+			if tt.quiet {
+				now := time.Now().UTC()
+				defaults.QuietHoursStart = now.Add(-time.Hour).Format("15:04")
+				defaults.QuietHoursEnd = now.Add(time.Hour).Format("15:04")
+			}
+			schedule := &fakeSchedule{reserved: tt.reserved}
+			dedup := NewMemoryDedup(100)
+			router, err := NewRouter(zap.NewNop(), noPreferences{}, defaults,
+				notifiers[ChannelConsole], notifiers[ChannelEmail], notifiers[ChannelWebhook])
+			if err != nil {
+				t.Fatal(err)
+			}
+			router.WithSchedule(schedule, []string{ChannelWebhook}).WithDedup(dedup, time.Hour, time.Minute)
+
+			//начало далеко впереди, чтобы отложенное напоминание не отбрасывалось
+			start := time.Now().UTC().Add(48 * time.Hour)
+			event := structs.Event{UUID: "event", Owner: "owner", Urgent: tt.urgent, EventDurationStart: start, EventDurationStop: start.Add(time.Hour)}
+			reminder := structs.DefaultReminder(event)
+			for _, channel := range tt.sent {
+				dedup.ConfirmReminder(ctx, structs.ReminderId(event.UUID, reminder, channel), time.Hour)
+			}
+
+			err = router.Notify(ctx, event, reminder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify error %v, want error %v", err, tt.wantErr)
+			}
+
+			for channel, n := range notifiers {
+				want := 0
+				for _, c := range tt.calls {
+					if c == channel {
+						want = 1
+					}
+				}
+				if n.calls != want {
+					t.Errorf("%v called %v times, want %v", channel, n.calls, want)
+				}
+			}
+			if deferred := len(schedule.deferred) > 0; deferred != tt.deferred {
+				t.Errorf("deferred %v, want %v", deferred, tt.deferred)
+			}
+			if schedule.reserved != tt.wantCap {
+				t.Errorf("daily count %v, want %v", schedule.reserved, tt.wantCap)
+			}
+			for _, channel := range tt.confirm {
+				claimed, err := dedup.ClaimReminder(ctx, structs.ReminderId(event.UUID, reminder, channel), time.Minute)
+				if claimed || err != nil {
+					t.Errorf("%v is not confirmed: claimed %v, error %v", channel, claimed, err)
+				}
+			}
+			for _, channel := range tt.release {
+				claimed, err := dedup.ClaimReminder(ctx, structs.ReminderId(event.UUID, reminder, channel), time.Minute)
+				if !claimed || err != nil {
+					t.Errorf("%v is not released: claimed %v, error %v", channel, claimed, err)
+				}
+			}
+		})
+	}
+}
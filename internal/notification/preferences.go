@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"calendar/internal/config"
+	"context"
+	"strings"
+)
+
+//StaticPreferences - настройки владельцев из конфига
+type StaticPreferences map[string]Preferences
+
+//Preferences ищет владельца без учета регистра, потому что viper приводит ключи к нижнему регистру
+func (sp StaticPreferences) Preferences(ctx context.Context, owner string) (Preferences, bool, error) {
+	prefs, ok := sp[strings.ToLower(owner)]
+	return prefs, ok, nil
+}
+
+//PreferencesFromConfig переводит секцию notification.owners в настройки владельцев
+func PreferencesFromConfig(owners map[string]config.OwnerConfig) StaticPreferences {
+	sp := make(StaticPreferences)
+	for owner, values := range owners {
+		sp[strings.ToLower(owner)] = Preferences{
+			Owner:           owner,
+			Channels:        values.Channels,
+			Email:           values.Email,
+			WebhookURL:      values.Webhook,
+			Locale:          values.Locale,
+			TimeZone:        values.TimeZone,
+			ReminderOffsets: values.ReminderOffsets,
+			QuietHoursStart: values.QuietHours.Start,
+			QuietHoursEnd:   values.QuietHours.End,
+			DailyCap:        values.DailyCap,
+		}
+	}
+	return sp
+}
+
+//PreferenceFunc позволяет использовать функцию как PreferenceSource
+type PreferenceFunc func(ctx context.Context, owner string) (Preferences, bool, error)
+
+func (f PreferenceFunc) Preferences(ctx context.Context, owner string) (Preferences, bool, error) {
+	return f(ctx, owner)
+}
+
+//LayeredPreferences берет настройки из первого источника, в котором они есть
+type LayeredPreferences []PreferenceSource
+
+func (lp LayeredPreferences) Preferences(ctx context.Context, owner string) (Preferences, bool, error) {
+	for _, source := range lp {
+		prefs, ok, err := source.Preferences(ctx, owner)
+		if err != nil || ok {
+			return prefs, ok, err
+		}
+	}
+	return Preferences{}, false, nil
+}
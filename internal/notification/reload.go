@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/structs"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"sync/atomic"
+)
+
+//ReloadableRouter пересоздает роутер без перезапуска. Dedup в памяти и лимиты переходят к новому роутеру
+type ReloadableRouter struct {
+	current atomic.Value
+	logger  *zap.Logger
+	stores  Stores
+}
+
+func NewReloadableRouter(logger *zap.Logger, config config.NotificationConfig, stores Stores) (*ReloadableRouter, error) {
+	router, err := NewRouterFromConfig(logger, config, stores)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := &ReloadableRouter{
+		logger: logger,
+		stores: stores,
+	}
+	rr.current.Store(router)
+	return rr, nil
+}
+
+func (rr *ReloadableRouter) Router() *Router {
+	return rr.current.Load().(*Router)
+}
+
+func (rr *ReloadableRouter) Notify(ctx context.Context, event structs.Event, reminder structs.Reminder) error {
+	return rr.Router().Notify(ctx, event, reminder)
+}
+
+//Reload создает роутер по новым настройкам. При ошибке остается прежний роутер
+func (rr *ReloadableRouter) Reload(config config.NotificationConfig) error {
+	previous, _ := rr.Router().notifiers[ChannelConsole].(*ConsoleNotifier)
+	router, err := newRouterFromConfig(rr.logger, config, rr.stores, previous)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := router.dedup.(*MemoryDedup); ok {
+		if memory, ok := rr.Router().dedup.(*MemoryDedup); ok {
+			router.WithDedup(memory, config.Dedup.TTL, config.Dedup.Lease)
+		}
+	}
+	router.limiter = rr.Router().limiter
+	router.limiter.set(config.Concurrency)
+	rr.current.Store(router)
+
+	//старый файл закрывается после замены, начатые в него записи дописываются
+	if console, _ := router.notifiers[ChannelConsole].(*ConsoleNotifier); previous != nil && (console == nil || console.out != previous.out) {
+		err = previous.Close()
+		if err != nil {
+			rr.logger.Error(fmt.Sprintf("Close console output %v error %v", previous.out.name, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/structs"
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//TestReloadConsoleOutput проверяет, что при перечитывании конфига файл консоли не открывается заново,
+//а после смены output старый файл закрывается
+func TestReloadConsoleOutput(t *testing.T) {
+	dir := t.TempDir()
+	notificationConfig := config.NotificationConfig{
+		Channels:        []string{ChannelConsole},
+		DefaultChannels: []string{ChannelConsole},
+		Locale:          "en",
+		TimeZone:        "UTC",
+		Locales:         "../../configs/locales",
+		Dedup:           config.DedupConfig{Backend: DedupMemory, Size: 100, TTL: time.Hour, Lease: time.Minute},
+		Console:         config.ConsoleConfig{Output: filepath.Join(dir, "first.log")},
+	}
+	rr, err := NewReloadableRouter(zap.NewNop(), notificationConfig, Stores{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	console := func() *ConsoleNotifier {
+		return rr.Router().notifiers[ChannelConsole].(*ConsoleNotifier)
+	}
+	first := console()
+
+	notificationConfig.Locale = "ru"
+	err = rr.Reload(notificationConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if console().out != first.out {
+		t.Fatal("unchanged output was reopened")
+	}
+
+	notificationConfig.Console.Output = filepath.Join(dir, "second.log")
+	err = rr.Reload(notificationConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := structs.Event{UUID: "event", Owner: "owner", Header: "meeting"}
+	err = first.Notify(context.Background(), Recipient{Location: time.UTC}, event)
+	if !errors.Is(err, os.ErrClosed) {
+		t.Errorf("old output is not closed: %v", err)
+	}
+	err = console().Notify(context.Background(), Recipient{Location: time.UTC}, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := os.ReadFile(filepath.Join(dir, "second.log"))
+	if err != nil || len(text) == 0 {
+		t.Errorf("nothing written to the new output: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"calendar/internal/structs"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+//ScheduleStore хранит суточные счетчики оповещений и отложенные напоминания
+type ScheduleStore interface {
+	//ReserveDailyNotification учитывает оповещение владельца за день day. false, если лимит уже исчерпан
+	ReserveDailyNotification(ctx context.Context, owner string, day time.Time, limit int32) (bool, error)
+	//ReleaseDailyNotification возвращает оповещение, которое не удалось доставить ни по одному каналу
+	ReleaseDailyNotification(ctx context.Context, owner string, day time.Time) error
+	//DeferReminder сохраняет напоминание для повторной доставки в until
+	DeferReminder(ctx context.Context, event structs.Event, reminder structs.Reminder, until time.Time, reason string) error
+}
+
+//parseClock разбирает время суток ЧЧ:ММ в минуты от полуночи
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %v, expected HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+//quietUntil возвращает конец тихих часов [start, end), интервал может переходить через полночь
+func quietUntil(local time.Time, start string, end string) (time.Time, bool, error) {
+	if start == "" || end == "" || start == end {
+		return time.Time{}, false, nil
+	}
+	from, err := parseClock(start)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	now := local.Hour()*60 + local.Minute()
+	endToday := time.Date(local.Year(), local.Month(), local.Day(), 0, to, 0, 0, local.Location())
+
+	if from < to {
+		if now >= from && now < to {
+			return endToday, true, nil
+		}
+		return time.Time{}, false, nil
+	}
+
+	//через полночь: до конца сегодня утром или после начала сегодня вечером
+	if now < to {
+		return endToday, true, nil
+	}
+	if now >= from {
+		return time.Date(local.Year(), local.Month(), local.Day()+1, 0, to, 0, 0, local.Location()), true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+//ValidatePreferences проверяет настройки владельца перед сохранением
+func ValidatePreferences(prefs Preferences) error {
+	if prefs.Owner == "" {
+		return errors.New("owner is required")
+	}
+	for _, channel := range prefs.Channels {
+		if channel != ChannelConsole && channel != ChannelEmail && channel != ChannelWebhook {
+			return fmt.Errorf("unknown notification channel %v", channel)
+		}
+	}
+	if prefs.WebhookURL != "" {
+		u, err := url.Parse(prefs.WebhookURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.New("webhook url must be http or https")
+		}
+		if u.Host == "" {
+			return errors.New("webhook url must have a host")
+		}
+	}
+	if prefs.TimeZone != "" {
+		_, err := time.LoadLocation(prefs.TimeZone)
+		if err != nil {
+			return fmt.Errorf("unknown time zone %v", prefs.TimeZone)
+		}
+	}
+	if (prefs.QuietHoursStart == "") != (prefs.QuietHoursEnd == "") {
+		return errors.New("quiet hours need both start and end")
+	}
+	if prefs.QuietHoursStart != "" {
+		_, err := parseClock(prefs.QuietHoursStart)
+		if err != nil {
+			return err
+		}
+		_, err = parseClock(prefs.QuietHoursEnd)
+		if err != nil {
+			return err
+		}
+	}
+	for _, offset := range prefs.ReminderOffsets {
+		if offset < 0 {
+			return fmt.Errorf("reminder offset %v must not be negative", offset)
+		}
+	}
+	if prefs.DailyCap < 0 {
+		return errors.New("daily cap must not be negative")
+	}
+	return nil
+}
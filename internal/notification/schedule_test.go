@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestQuietUntil(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		local     time.Time
+		start     string
+		end       string
+		wantQuiet bool
+		wantUntil time.Time
+	}{
+		{"disabled", time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC), "", "", false, time.Time{}},
+		{"equal bounds", time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC), "22:00", "22:00", false, time.Time{}},
+		{"inside day window", time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), "09:00", "18:00", true, time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)},
+		{"day window start", time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), "09:00", "18:00", true, time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)},
+		{"day window end is exclusive", time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC), "09:00", "18:00", false, time.Time{}},
+		{"before midnight", time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC), "22:00", "08:00", true, time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{"after midnight", time.Date(2024, 5, 2, 7, 59, 0, 0, time.UTC), "22:00", "08:00", true, time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{"outside night window", time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC), "22:00", "08:00", false, time.Time{}},
+		{"end of month", time.Date(2024, 4, 30, 22, 30, 0, 0, time.UTC), "22:00", "08:00", true, time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)},
+		//переход на летнее время: ночь короче на час, конец - 08:00 по новому смещению
+		{"spring forward", time.Date(2024, 3, 30, 23, 0, 0, 0, berlin), "22:00", "08:00", true, time.Date(2024, 3, 31, 6, 0, 0, 0, time.UTC)},
+		{"fall back", time.Date(2024, 10, 26, 23, 0, 0, 0, berlin), "22:00", "08:00", true, time.Date(2024, 10, 27, 7, 0, 0, 0, time.UTC)},
+		{"during spring forward gap", time.Date(2024, 3, 31, 3, 30, 0, 0, berlin), "22:00", "08:00", true, time.Date(2024, 3, 31, 6, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet, err := quietUntil(tt.local, tt.start, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quiet != tt.wantQuiet || !until.Equal(tt.wantUntil) {
+				t.Errorf("got %v until %v, want %v until %v", quiet, until, tt.wantQuiet, tt.wantUntil)
+			}
+		})
+	}
+
+	_, _, err = quietUntil(time.Now(), "25:00", "08:00")
+	if err == nil {
+		t.Error("invalid start accepted")
+	}
+}
+
+func TestValidatePreferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefs   Preferences
+		wantErr string
+	}{
+		{"valid", Preferences{Owner: "owner", Channels: []string{ChannelEmail, ChannelWebhook}, TimeZone: "Europe/Moscow",
+			QuietHoursStart: "22:00", QuietHoursEnd: "08:00", ReminderOffsets: []int32{0, 15}, DailyCap: 5}, ""},
+		{"defaults only", Preferences{Owner: "owner"}, ""},
+		{"webhook url", Preferences{Owner: "owner", WebhookURL: "https://hooks.example.com/calendar"}, ""},
+		{"webhook url scheme", Preferences{Owner: "owner", WebhookURL: "ftp://hooks.example.com"}, "must be http or https"},
+		{"webhook url without scheme", Preferences{Owner: "owner", WebhookURL: "hooks.example.com/calendar"}, "must be http or https"},
+		{"webhook url without host", Preferences{Owner: "owner", WebhookURL: "http:///calendar"}, "must have a host"},
+		{"malformed webhook url", Preferences{Owner: "owner", WebhookURL: "http://[::1"}, "missing ']'"},
+		{"no owner", Preferences{}, "owner is required"},
+		{"unknown channel", Preferences{Owner: "owner", Channels: []string{"sms"}}, "unknown notification channel sms"},
+		{"unknown time zone", Preferences{Owner: "owner", TimeZone: "Mars/Olympus"}, "unknown time zone"},
+		{"quiet hours without end", Preferences{Owner: "owner", QuietHoursStart: "22:00"}, "both start and end"},
+		{"invalid quiet hours", Preferences{Owner: "owner", QuietHoursStart: "22:00", QuietHoursEnd: "8am"}, "invalid time of day"},
+		{"negative offset", Preferences{Owner: "owner", ReminderOffsets: []int32{-5}}, "must not be negative"},
+		{"negative daily cap", Preferences{Owner: "owner", DailyCap: -1}, "daily cap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePreferences(tt.prefs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
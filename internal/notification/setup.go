@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/i18n"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+//режимы хранения отправленных напоминаний
+const (
+	DedupMemory   = "memory"
+	DedupPostgres = "postgres"
+)
+
+//Stores - постоянные хранилища нотификатора, любое может быть nil
+type Stores struct {
+	Preferences PreferenceSource
+	Schedule    ScheduleStore
+	Deliveries  DeliveryLog
+	Dedup       DedupStore
+}
+
+//настройки владельца берутся из stores.Preferences, а если их там нет - из notification.owners
+func NewRouterFromConfig(logger *zap.Logger, config config.NotificationConfig, stores Stores) (*Router, error) {
+	return newRouterFromConfig(logger, config, stores, nil)
+}
+
+//newRouterFromConfig пишет в вывод previous, если notification.console.output не изменился
+func newRouterFromConfig(logger *zap.Logger, config config.NotificationConfig, stores Stores, previous *ConsoleNotifier) (router *Router, err error) {
+	defaults := Preferences{
+		Channels:        config.DefaultChannels,
+		Locale:          config.Locale,
+		TimeZone:        config.TimeZone,
+		QuietHoursStart: config.QuietHours.Start,
+		QuietHoursEnd:   config.QuietHours.End,
+		DailyCap:        config.DailyCap,
+	}
+
+	bundle, err := i18n.LoadBundle(config.Locales, defaults.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	notifiers := make([]Notifier, 0)
+	for _, channel := range config.Channels {
+		switch channel {
+		case ChannelConsole:
+			console, reused := previous.reuse(config.Console.Output, bundle)
+			if !reused {
+				console, err = NewConsoleNotifier(config.Console.Output, bundle)
+				if err != nil {
+					return nil, err
+				}
+				defer func() {
+					if err != nil {
+						console.Close()
+					}
+				}()
+			}
+			notifiers = append(notifiers, console)
+		case ChannelEmail:
+			email, err := NewEmailNotifier(SMTPConfig{
+				Host:               config.SMTP.Host,
+				Port:               config.SMTP.Port,
+				User:               config.SMTP.User,
+				Password:           config.SMTP.Password,
+				From:               config.SMTP.From,
+				StartTLS:           config.SMTP.StartTLS,
+				InsecureSkipVerify: config.SMTP.InsecureSkipVerify,
+				TemplatesDir:       config.SMTP.Templates,
+				Timeout:            config.SMTP.Timeout,
+			}, bundle)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, email)
+		case ChannelWebhook:
+			notifiers = append(notifiers, NewWebhookNotifier(config.Webhook.URL, config.Webhook.Timeout))
+		default:
+			return nil, fmt.Errorf("unknown notification channel %v", channel)
+		}
+	}
+
+	var preferences PreferenceSource = PreferencesFromConfig(config.Owners)
+	if stores.Preferences != nil {
+		preferences = LayeredPreferences{stores.Preferences, preferences}
+	}
+
+	router, err = NewRouter(logger, preferences, defaults, notifiers...)
+	if err != nil {
+		return nil, err
+	}
+	if stores.Schedule != nil {
+		router.WithSchedule(stores.Schedule, config.EscalationChannels)
+	}
+	if stores.Deliveries != nil {
+		router.WithDeliveryLog(stores.Deliveries)
+	}
+	router.WithConcurrency(config.Concurrency)
+
+	switch config.Dedup.Backend {
+	case DedupMemory:
+		router.WithDedup(NewMemoryDedup(config.Dedup.Size), config.Dedup.TTL, config.Dedup.Lease)
+	case DedupPostgres:
+		if stores.Dedup == nil {
+			return nil, fmt.Errorf("dedup backend %v is not available", config.Dedup.Backend)
+		}
+		router.WithDedup(stores.Dedup, config.Dedup.TTL, config.Dedup.Lease)
+	default:
+		return nil, fmt.Errorf("unknown dedup backend %v", config.Dedup.Backend)
+	}
+	return router, nil
+}
@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"bytes"
+	"calendar/internal/structs"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//WebhookNotifier отправляет оповещение POST-запросом с JSON
+type WebhookNotifier struct {
+	defaultURL string
+	client     *http.Client
+}
+
+func NewWebhookNotifier(defaultURL string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		defaultURL: defaultURL,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (w *WebhookNotifier) Name() string {
+	return ChannelWebhook
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, recipient Recipient, d structs.Event) error {
+	url := recipient.WebhookURL
+	if url == "" {
+		url = w.defaultURL
+	}
+	if url == "" {
+		return &BounceError{errors.New("recipient has no webhook url")}
+	}
+
+	body, err := json.Marshal(structs.NewEnvelope(structs.ReminderDue, d))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = fmt.Errorf("webhook responded with %v", resp.Status)
+		//4xx не исправятся повтором, кроме таймаута и ограничения частоты
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return &BounceError{err}
+		}
+		return err
+	}
+	return nil
+}
@@ -205,48 +205,968 @@ func (m *GetRequest) GetDateTime() *timestamp.Timestamp {
 	return nil
 }
 
+type WebhookSubscription struct {
+	UUID                 string               `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Owner                string               `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Url                  string               `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Secret               string               `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes           []string             `protobuf:"bytes,5,rep,name=eventTypes,proto3" json:"eventTypes,omitempty"`
+	Active               bool                 `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookSubscription) Reset()         { *m = WebhookSubscription{} }
+func (m *WebhookSubscription) String() string { return proto.CompactTextString(m) }
+func (*WebhookSubscription) ProtoMessage()    {}
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{4}
+}
+
+func (m *WebhookSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookSubscription.Unmarshal(m, b)
+}
+func (m *WebhookSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookSubscription.Marshal(b, m, deterministic)
+}
+func (m *WebhookSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookSubscription.Merge(m, src)
+}
+func (m *WebhookSubscription) XXX_Size() int {
+	return xxx_messageInfo_WebhookSubscription.Size(m)
+}
+func (m *WebhookSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookSubscription proto.InternalMessageInfo
+
+func (m *WebhookSubscription) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *WebhookSubscription) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *WebhookSubscription) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type WebhookRequest struct {
+	UUID                 string   `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookRequest) Reset()         { *m = WebhookRequest{} }
+func (m *WebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*WebhookRequest) ProtoMessage()    {}
+func (*WebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{5}
+}
+
+func (m *WebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookRequest.Unmarshal(m, b)
+}
+func (m *WebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *WebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookRequest.Merge(m, src)
+}
+func (m *WebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_WebhookRequest.Size(m)
+}
+func (m *WebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookRequest proto.InternalMessageInfo
+
+func (m *WebhookRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+type WebhookResult struct {
+	Error                string               `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Subscription         *WebhookSubscription `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookResult) Reset()         { *m = WebhookResult{} }
+func (m *WebhookResult) String() string { return proto.CompactTextString(m) }
+func (*WebhookResult) ProtoMessage()    {}
+func (*WebhookResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{6}
+}
+
+func (m *WebhookResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookResult.Unmarshal(m, b)
+}
+func (m *WebhookResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookResult.Marshal(b, m, deterministic)
+}
+func (m *WebhookResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookResult.Merge(m, src)
+}
+func (m *WebhookResult) XXX_Size() int {
+	return xxx_messageInfo_WebhookResult.Size(m)
+}
+func (m *WebhookResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookResult proto.InternalMessageInfo
+
+func (m *WebhookResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *WebhookResult) GetSubscription() *WebhookSubscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{7}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+type ListWebhooksResult struct {
+	Error                string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Subscriptions        []*WebhookSubscription `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListWebhooksResult) Reset()         { *m = ListWebhooksResult{} }
+func (m *ListWebhooksResult) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResult) ProtoMessage()    {}
+func (*ListWebhooksResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{8}
+}
+
+func (m *ListWebhooksResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResult.Unmarshal(m, b)
+}
+func (m *ListWebhooksResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResult.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResult.Merge(m, src)
+}
+func (m *ListWebhooksResult) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResult.Size(m)
+}
+func (m *ListWebhooksResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResult proto.InternalMessageInfo
+
+func (m *ListWebhooksResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ListWebhooksResult) GetSubscriptions() []*WebhookSubscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MessageId            string               `protobuf:"bytes,2,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	EventType            string               `protobuf:"bytes,3,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	Status               string               `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts             int32                `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	ResponseCode         int32                `protobuf:"varint,6,opt,name=responseCode,proto3" json:"responseCode,omitempty"`
+	Error                string               `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	NextAttemptAt        *timestamp.Timestamp `protobuf:"bytes,9,opt,name=nextAttemptAt,proto3" json:"nextAttemptAt,omitempty"`
+	LastAttemptAt        *timestamp.Timestamp `protobuf:"bytes,10,opt,name=lastAttemptAt,proto3" json:"lastAttemptAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{9}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetResponseCode() int32 {
+	if m != nil {
+		return m.ResponseCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetLastAttemptAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAttemptAt
+	}
+	return nil
+}
+
+type WebhookDeliveriesRequest struct {
+	SubscriptionUUID     string   `protobuf:"bytes,1,opt,name=subscriptionUUID,proto3" json:"subscriptionUUID,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDeliveriesRequest) Reset()         { *m = WebhookDeliveriesRequest{} }
+func (m *WebhookDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*WebhookDeliveriesRequest) ProtoMessage()    {}
+func (*WebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{10}
+}
+
+func (m *WebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *WebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *WebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *WebhookDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_WebhookDeliveriesRequest.Size(m)
+}
+func (m *WebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *WebhookDeliveriesRequest) GetSubscriptionUUID() string {
+	if m != nil {
+		return m.SubscriptionUUID
+	}
+	return ""
+}
+
+func (m *WebhookDeliveriesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type WebhookDeliveriesResult struct {
+	Error                string             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Deliveries           []*WebhookDelivery `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *WebhookDeliveriesResult) Reset()         { *m = WebhookDeliveriesResult{} }
+func (m *WebhookDeliveriesResult) String() string { return proto.CompactTextString(m) }
+func (*WebhookDeliveriesResult) ProtoMessage()    {}
+func (*WebhookDeliveriesResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{11}
+}
+
+func (m *WebhookDeliveriesResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDeliveriesResult.Unmarshal(m, b)
+}
+func (m *WebhookDeliveriesResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDeliveriesResult.Marshal(b, m, deterministic)
+}
+func (m *WebhookDeliveriesResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDeliveriesResult.Merge(m, src)
+}
+func (m *WebhookDeliveriesResult) XXX_Size() int {
+	return xxx_messageInfo_WebhookDeliveriesResult.Size(m)
+}
+func (m *WebhookDeliveriesResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDeliveriesResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDeliveriesResult proto.InternalMessageInfo
+
+func (m *WebhookDeliveriesResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *WebhookDeliveriesResult) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type NotificationPreferences struct {
+	Owner                string               `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Channels             []string             `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	Email                string               `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	WebhookUrl           string               `protobuf:"bytes,4,opt,name=webhookUrl,proto3" json:"webhookUrl,omitempty"`
+	Locale               string               `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	TimeZone             string               `protobuf:"bytes,6,opt,name=timeZone,proto3" json:"timeZone,omitempty"`
+	ReminderOffsets      []int32              `protobuf:"varint,7,rep,packed,name=reminderOffsets,proto3" json:"reminderOffsets,omitempty"`
+	QuietHoursStart      string               `protobuf:"bytes,8,opt,name=quietHoursStart,proto3" json:"quietHoursStart,omitempty"`
+	QuietHoursEnd        string               `protobuf:"bytes,9,opt,name=quietHoursEnd,proto3" json:"quietHoursEnd,omitempty"`
+	DailyCap             int32                `protobuf:"varint,10,opt,name=dailyCap,proto3" json:"dailyCap,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NotificationPreferences) Reset()         { *m = NotificationPreferences{} }
+func (m *NotificationPreferences) String() string { return proto.CompactTextString(m) }
+func (*NotificationPreferences) ProtoMessage()    {}
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{12}
+}
+
+func (m *NotificationPreferences) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationPreferences.Unmarshal(m, b)
+}
+func (m *NotificationPreferences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationPreferences.Marshal(b, m, deterministic)
+}
+func (m *NotificationPreferences) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationPreferences.Merge(m, src)
+}
+func (m *NotificationPreferences) XXX_Size() int {
+	return xxx_messageInfo_NotificationPreferences.Size(m)
+}
+func (m *NotificationPreferences) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationPreferences.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationPreferences proto.InternalMessageInfo
+
+func (m *NotificationPreferences) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *NotificationPreferences) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetWebhookUrl() string {
+	if m != nil {
+		return m.WebhookUrl
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetReminderOffsets() []int32 {
+	if m != nil {
+		return m.ReminderOffsets
+	}
+	return nil
+}
+
+func (m *NotificationPreferences) GetQuietHoursStart() string {
+	if m != nil {
+		return m.QuietHoursStart
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetQuietHoursEnd() string {
+	if m != nil {
+		return m.QuietHoursEnd
+	}
+	return ""
+}
+
+func (m *NotificationPreferences) GetDailyCap() int32 {
+	if m != nil {
+		return m.DailyCap
+	}
+	return 0
+}
+
+func (m *NotificationPreferences) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type PreferencesRequest struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PreferencesRequest) Reset()         { *m = PreferencesRequest{} }
+func (m *PreferencesRequest) String() string { return proto.CompactTextString(m) }
+func (*PreferencesRequest) ProtoMessage()    {}
+func (*PreferencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{13}
+}
+
+func (m *PreferencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreferencesRequest.Unmarshal(m, b)
+}
+func (m *PreferencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreferencesRequest.Marshal(b, m, deterministic)
+}
+func (m *PreferencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreferencesRequest.Merge(m, src)
+}
+func (m *PreferencesRequest) XXX_Size() int {
+	return xxx_messageInfo_PreferencesRequest.Size(m)
+}
+func (m *PreferencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreferencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreferencesRequest proto.InternalMessageInfo
+
+func (m *PreferencesRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+type PreferencesResult struct {
+	Error                string                   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Preferences          *NotificationPreferences `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *PreferencesResult) Reset()         { *m = PreferencesResult{} }
+func (m *PreferencesResult) String() string { return proto.CompactTextString(m) }
+func (*PreferencesResult) ProtoMessage()    {}
+func (*PreferencesResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{14}
+}
+
+func (m *PreferencesResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreferencesResult.Unmarshal(m, b)
+}
+func (m *PreferencesResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreferencesResult.Marshal(b, m, deterministic)
+}
+func (m *PreferencesResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreferencesResult.Merge(m, src)
+}
+func (m *PreferencesResult) XXX_Size() int {
+	return xxx_messageInfo_PreferencesResult.Size(m)
+}
+func (m *PreferencesResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreferencesResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreferencesResult proto.InternalMessageInfo
+
+func (m *PreferencesResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *PreferencesResult) GetPreferences() *NotificationPreferences {
+	if m != nil {
+		return m.Preferences
+	}
+	return nil
+}
+
+type ReminderDelivery struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventUUID            string               `protobuf:"bytes,2,opt,name=eventUUID,proto3" json:"eventUUID,omitempty"`
+	Owner                string               `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Channel              string               `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Status               string               `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Error                string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	AttemptedAt          *timestamp.Timestamp `protobuf:"bytes,7,opt,name=attemptedAt,proto3" json:"attemptedAt,omitempty"`
+	DeferredTo           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=deferredTo,proto3" json:"deferredTo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ReminderDelivery) Reset()         { *m = ReminderDelivery{} }
+func (m *ReminderDelivery) String() string { return proto.CompactTextString(m) }
+func (*ReminderDelivery) ProtoMessage()    {}
+func (*ReminderDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{15}
+}
+
+func (m *ReminderDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReminderDelivery.Unmarshal(m, b)
+}
+func (m *ReminderDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReminderDelivery.Marshal(b, m, deterministic)
+}
+func (m *ReminderDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReminderDelivery.Merge(m, src)
+}
+func (m *ReminderDelivery) XXX_Size() int {
+	return xxx_messageInfo_ReminderDelivery.Size(m)
+}
+func (m *ReminderDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReminderDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReminderDelivery proto.InternalMessageInfo
+
+func (m *ReminderDelivery) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ReminderDelivery) GetEventUUID() string {
+	if m != nil {
+		return m.EventUUID
+	}
+	return ""
+}
+
+func (m *ReminderDelivery) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *ReminderDelivery) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ReminderDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ReminderDelivery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ReminderDelivery) GetAttemptedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.AttemptedAt
+	}
+	return nil
+}
+
+func (m *ReminderDelivery) GetDeferredTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeferredTo
+	}
+	return nil
+}
+
+type ReminderStatusRequest struct {
+	EventUUID            string   `protobuf:"bytes,1,opt,name=eventUUID,proto3" json:"eventUUID,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReminderStatusRequest) Reset()         { *m = ReminderStatusRequest{} }
+func (m *ReminderStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ReminderStatusRequest) ProtoMessage()    {}
+func (*ReminderStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{16}
+}
+
+func (m *ReminderStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReminderStatusRequest.Unmarshal(m, b)
+}
+func (m *ReminderStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReminderStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ReminderStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReminderStatusRequest.Merge(m, src)
+}
+func (m *ReminderStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ReminderStatusRequest.Size(m)
+}
+func (m *ReminderStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReminderStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReminderStatusRequest proto.InternalMessageInfo
+
+func (m *ReminderStatusRequest) GetEventUUID() string {
+	if m != nil {
+		return m.EventUUID
+	}
+	return ""
+}
+
+func (m *ReminderStatusRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ReminderStatusResult struct {
+	Error                string              `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Deliveries           []*ReminderDelivery `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ReminderStatusResult) Reset()         { *m = ReminderStatusResult{} }
+func (m *ReminderStatusResult) String() string { return proto.CompactTextString(m) }
+func (*ReminderStatusResult) ProtoMessage()    {}
+func (*ReminderStatusResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cac38fe7d323f2d0, []int{17}
+}
+
+func (m *ReminderStatusResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReminderStatusResult.Unmarshal(m, b)
+}
+func (m *ReminderStatusResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReminderStatusResult.Marshal(b, m, deterministic)
+}
+func (m *ReminderStatusResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReminderStatusResult.Merge(m, src)
+}
+func (m *ReminderStatusResult) XXX_Size() int {
+	return xxx_messageInfo_ReminderStatusResult.Size(m)
+}
+func (m *ReminderStatusResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReminderStatusResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReminderStatusResult proto.InternalMessageInfo
+
+func (m *ReminderStatusResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ReminderStatusResult) GetDeliveries() []*ReminderDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChangeEventRequest)(nil), "calendar.changeEventRequest")
 	proto.RegisterType((*ChangeEventResult)(nil), "calendar.changeEventResult")
 	proto.RegisterType((*GetResult)(nil), "calendar.getResult")
 	proto.RegisterType((*GetRequest)(nil), "calendar.getRequest")
+	proto.RegisterType((*WebhookSubscription)(nil), "calendar.webhookSubscription")
+	proto.RegisterType((*WebhookRequest)(nil), "calendar.webhookRequest")
+	proto.RegisterType((*WebhookResult)(nil), "calendar.webhookResult")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "calendar.listWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResult)(nil), "calendar.listWebhooksResult")
+	proto.RegisterType((*WebhookDelivery)(nil), "calendar.webhookDelivery")
+	proto.RegisterType((*WebhookDeliveriesRequest)(nil), "calendar.webhookDeliveriesRequest")
+	proto.RegisterType((*WebhookDeliveriesResult)(nil), "calendar.webhookDeliveriesResult")
+	proto.RegisterType((*NotificationPreferences)(nil), "calendar.notificationPreferences")
+	proto.RegisterType((*PreferencesRequest)(nil), "calendar.preferencesRequest")
+	proto.RegisterType((*PreferencesResult)(nil), "calendar.preferencesResult")
+	proto.RegisterType((*ReminderDelivery)(nil), "calendar.reminderDelivery")
+	proto.RegisterType((*ReminderStatusRequest)(nil), "calendar.reminderStatusRequest")
+	proto.RegisterType((*ReminderStatusResult)(nil), "calendar.reminderStatusResult")
 }
 
 func init() { proto.RegisterFile("API.proto", fileDescriptor_cac38fe7d323f2d0) }
 
 var fileDescriptor_cac38fe7d323f2d0 = []byte{
-	// 339 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x91, 0x6f, 0x4b, 0xc2, 0x50,
-	0x14, 0xc6, 0x53, 0x51, 0xdc, 0x59, 0x68, 0x5d, 0x25, 0x64, 0x05, 0xc9, 0x20, 0x10, 0x82, 0x09,
-	0x06, 0xbd, 0xb3, 0x10, 0xec, 0x85, 0xfd, 0x43, 0x86, 0xd0, 0xeb, 0xe9, 0x4e, 0xf3, 0xd2, 0xb6,
-	0x6b, 0xf7, 0x9e, 0x09, 0x7d, 0x97, 0x3e, 0x6c, 0x78, 0xef, 0x4c, 0x32, 0x8a, 0x90, 0x5e, 0x9e,
-	0xe7, 0x3c, 0xcf, 0x6f, 0xf7, 0x3c, 0x03, 0x6b, 0x30, 0x1e, 0x79, 0x0b, 0x29, 0x48, 0xb0, 0xea,
-	0x2c, 0x88, 0x31, 0x0d, 0x03, 0xe9, 0x9c, 0x46, 0x42, 0x44, 0x31, 0x76, 0xb5, 0x3e, 0xcd, 0x9e,
-	0xbb, 0xc4, 0x13, 0x54, 0x14, 0x24, 0x0b, 0x63, 0x75, 0xf6, 0x71, 0x89, 0x29, 0x29, 0x33, 0xb9,
-	0x77, 0xc0, 0x66, 0xf3, 0x20, 0x8d, 0xf0, 0x66, 0xa5, 0xfa, 0xf8, 0x9a, 0xa1, 0x22, 0x76, 0x06,
-	0x65, 0xed, 0x6a, 0x15, 0xda, 0x85, 0x8e, 0xdd, 0xab, 0x7b, 0x6b, 0xbc, 0x67, 0x6c, 0x66, 0xcb,
-	0x6a, 0x50, 0xe4, 0x61, 0xab, 0xd8, 0x2e, 0x74, 0x2c, 0xbf, 0xc8, 0x43, 0x77, 0x00, 0x87, 0x5f,
-	0x60, 0x2a, 0x8b, 0x89, 0x35, 0xa1, 0x8c, 0x52, 0x0a, 0xa9, 0x59, 0x96, 0x6f, 0x06, 0x76, 0x04,
-	0x15, 0xa9, 0xf7, 0x3a, 0x5e, 0xf5, 0xf3, 0xc9, 0x7d, 0x04, 0x2b, 0xc2, 0xdf, 0xa3, 0xe7, 0x50,
-	0x31, 0x27, 0xe8, 0xa8, 0xdd, 0x6b, 0x6c, 0xbd, 0xee, 0x9e, 0x2b, 0xf2, 0x73, 0x8b, 0x3b, 0x04,
-	0xd0, 0x3c, 0x73, 0xd7, 0x25, 0x54, 0xc3, 0x80, 0x70, 0xc2, 0x13, 0xcc, 0x4f, 0x73, 0x3c, 0xd3,
-	0x97, 0xb7, 0xee, 0xcb, 0x9b, 0xac, 0xfb, 0xf2, 0x3f, 0xbd, 0xbd, 0xf7, 0x12, 0x94, 0x06, 0xe3,
-	0x11, 0xeb, 0x83, 0xcd, 0x53, 0x85, 0x92, 0xf4, 0x87, 0xd8, 0x76, 0x2f, 0xce, 0xf1, 0x46, 0xf8,
-	0x56, 0x84, 0xbb, 0xc7, 0x6e, 0xc1, 0xce, 0x16, 0x2b, 0xa8, 0x89, 0x9f, 0xfc, 0xe0, 0xd6, 0x6f,
-	0xfd, 0x03, 0x4b, 0x62, 0x22, 0x96, 0xff, 0xc1, 0xea, 0x43, 0x2d, 0x42, 0x1a, 0x06, 0x3c, 0x7e,
-	0xd3, 0x0b, 0xc5, 0x9a, 0x9b, 0xc0, 0xa6, 0x3e, 0xa7, 0xb1, 0xa5, 0xe6, 0xf1, 0x2b, 0xa8, 0x47,
-	0x48, 0x4f, 0x88, 0x2f, 0xbb, 0xe5, 0xaf, 0xe1, 0x20, 0x42, 0x7a, 0x10, 0x29, 0xcd, 0x77, 0x02,
-	0x4c, 0x2b, 0xfa, 0xe7, 0x5d, 0x7c, 0x04, 0x00, 0x00, 0xff, 0xff, 0xf0, 0x38, 0xe5, 0x63, 0x11,
-	0x03, 0x00, 0x00,
+	// 1115 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x6d, 0x6f, 0xdc, 0x44,
+	0x10, 0xe6, 0x72, 0xbd, 0x24, 0x9e, 0xcb, 0xe5, 0x65, 0x13, 0x88, 0x71, 0xd3, 0x36, 0xb5, 0x8a,
+	0x74, 0x6a, 0xa5, 0xab, 0x14, 0x24, 0x04, 0x15, 0x05, 0x4e, 0x49, 0x25, 0x42, 0x69, 0x89, 0x9c,
+	0x84, 0x0a, 0x84, 0x84, 0x9c, 0xf3, 0xdc, 0x65, 0xa9, 0xcf, 0xbe, 0xee, 0xee, 0xa5, 0xe4, 0x23,
+	0x1f, 0xf8, 0xc0, 0x3f, 0xe0, 0xbf, 0xf1, 0x67, 0xd0, 0xbe, 0xd8, 0x5e, 0xdf, 0x8b, 0x2f, 0xb4,
+	0x7c, 0xf3, 0xcc, 0x3e, 0x33, 0x9e, 0x7d, 0xe6, 0xd9, 0xd9, 0x05, 0xa7, 0x7b, 0x72, 0xdc, 0x19,
+	0xb1, 0x54, 0xa4, 0x64, 0xb5, 0x17, 0xc6, 0x98, 0x44, 0x21, 0xf3, 0xee, 0x0d, 0xd2, 0x74, 0x10,
+	0xe3, 0x63, 0xe5, 0xbf, 0x18, 0xf7, 0x1f, 0x0b, 0x3a, 0x44, 0x2e, 0xc2, 0xe1, 0x48, 0x43, 0xbd,
+	0x35, 0xbc, 0xc2, 0x44, 0x70, 0x6d, 0xf9, 0xcf, 0x81, 0xf4, 0x2e, 0xc3, 0x64, 0x80, 0xcf, 0xa4,
+	0x37, 0xc0, 0x37, 0x63, 0xe4, 0x82, 0x7c, 0x02, 0x0d, 0x85, 0x72, 0x6b, 0xfb, 0xb5, 0x76, 0xf3,
+	0x60, 0xa3, 0x93, 0xa5, 0xef, 0x68, 0x98, 0x5e, 0x25, 0xeb, 0xb0, 0x44, 0x23, 0x77, 0x69, 0xbf,
+	0xd6, 0x76, 0x82, 0x25, 0x1a, 0xf9, 0x5d, 0xd8, 0x2a, 0x25, 0xe3, 0xe3, 0x58, 0x90, 0x1d, 0x68,
+	0x20, 0x63, 0x29, 0x53, 0xb9, 0x9c, 0x40, 0x1b, 0xe4, 0x23, 0x58, 0x66, 0x6a, 0x5d, 0x85, 0xaf,
+	0x06, 0xc6, 0xf2, 0x5f, 0x82, 0x33, 0xc0, 0xea, 0xd0, 0x47, 0xb0, 0xac, 0xb7, 0xa0, 0x42, 0x9b,
+	0x07, 0xdb, 0x13, 0xd5, 0x7d, 0x4f, 0xb9, 0x08, 0x0c, 0xc4, 0x3f, 0x02, 0x18, 0x60, 0xbe, 0xaf,
+	0xcf, 0x60, 0x35, 0x0a, 0x05, 0x9e, 0xd1, 0x21, 0x9a, 0xad, 0x79, 0x1d, 0xcd, 0x57, 0x27, 0xe3,
+	0xab, 0x73, 0x96, 0xf1, 0x15, 0xe4, 0x58, 0xff, 0x9f, 0x1a, 0x6c, 0xbf, 0xc5, 0x8b, 0xcb, 0x34,
+	0x7d, 0x7d, 0x3a, 0xbe, 0xe0, 0x3d, 0x46, 0x47, 0x82, 0xa6, 0x09, 0x21, 0x70, 0xeb, 0xfc, 0xfc,
+	0xf8, 0xc8, 0xd4, 0xa7, 0xbe, 0x65, 0xd1, 0xe9, 0xdb, 0x04, 0x99, 0xe1, 0x45, 0x1b, 0x64, 0x13,
+	0xea, 0x63, 0x16, 0xbb, 0x75, 0xe5, 0x93, 0x9f, 0x92, 0x01, 0x8e, 0x3d, 0x86, 0xc2, 0xbd, 0xa5,
+	0x9c, 0xc6, 0x22, 0x77, 0x01, 0x54, 0xed, 0x67, 0xd7, 0x23, 0xe4, 0x6e, 0x63, 0xbf, 0xde, 0x76,
+	0x02, 0xcb, 0x23, 0xe3, 0xc2, 0x9e, 0xa0, 0x57, 0xe8, 0x2e, 0x6b, 0xe6, 0xb4, 0x45, 0x3e, 0x07,
+	0xa7, 0xc7, 0x30, 0x14, 0x18, 0x75, 0x85, 0xbb, 0xb2, 0x70, 0x73, 0x05, 0xd8, 0x7f, 0x00, 0xeb,
+	0x66, 0x73, 0x19, 0x4f, 0x33, 0xf6, 0xe5, 0x5f, 0x42, 0x2b, 0x47, 0x55, 0x74, 0xa7, 0x0b, 0x6b,
+	0xdc, 0xa2, 0xc8, 0xf4, 0xe8, 0x4e, 0xd1, 0xa3, 0x19, 0x3c, 0x06, 0xa5, 0x10, 0xff, 0x11, 0x6c,
+	0xc7, 0x94, 0x8b, 0x57, 0x1a, 0xc8, 0xb3, 0xa2, 0x72, 0x62, 0x6b, 0x16, 0xb1, 0x7e, 0x0a, 0xa4,
+	0x0c, 0xae, 0xa8, 0xed, 0x10, 0x5a, 0xf6, 0x8f, 0xa4, 0x80, 0xea, 0x8b, 0x8b, 0x2b, 0xc7, 0xf8,
+	0x7f, 0xd5, 0x61, 0xc3, 0xc0, 0x8e, 0x30, 0xa6, 0x57, 0xc8, 0xae, 0xcd, 0x41, 0x90, 0xff, 0xaa,
+	0xcb, 0x83, 0x40, 0xf6, 0xc0, 0x19, 0x22, 0xe7, 0xe1, 0x00, 0x8f, 0xb3, 0xf3, 0x51, 0x38, 0xe4,
+	0x6a, 0xde, 0x4f, 0xa3, 0x88, 0xc2, 0xa1, 0x74, 0x21, 0x42, 0x31, 0xe6, 0xb9, 0x2e, 0x94, 0x45,
+	0x3c, 0x58, 0x0d, 0x85, 0xc0, 0xe1, 0x48, 0x48, 0x55, 0xd4, 0xda, 0x8d, 0x20, 0xb7, 0x89, 0x0f,
+	0x6b, 0x0c, 0xf9, 0x28, 0x4d, 0x38, 0x1e, 0xa6, 0x91, 0x56, 0x46, 0x23, 0x28, 0xf9, 0x0a, 0x4a,
+	0x56, 0x6c, 0x4a, 0x4a, 0xaa, 0x59, 0xfd, 0x0f, 0xaa, 0x21, 0xdf, 0x40, 0x2b, 0xc1, 0xdf, 0x45,
+	0x57, 0xd7, 0xd0, 0x15, 0xae, 0xb3, 0x30, 0xba, 0x1c, 0x20, 0x33, 0xc4, 0x21, 0xb7, 0x32, 0xc0,
+	0xe2, 0x0c, 0xa5, 0x00, 0xff, 0x17, 0x70, 0xcb, 0xad, 0xa0, 0x98, 0xcb, 0xe5, 0x21, 0x6c, 0xda,
+	0x8d, 0xb3, 0xf4, 0x3c, 0xe5, 0x97, 0xdc, 0xc4, 0x74, 0x48, 0xf5, 0x30, 0x6a, 0x04, 0xda, 0xf0,
+	0x7f, 0x83, 0xdd, 0x19, 0xd9, 0x2b, 0xf4, 0xf5, 0x05, 0x40, 0x94, 0x23, 0x8d, 0xb8, 0x3e, 0x9e,
+	0x12, 0x57, 0xa6, 0x9a, 0xc0, 0x02, 0xfb, 0x7f, 0xd6, 0x61, 0x37, 0x49, 0x05, 0xed, 0xd3, 0x5e,
+	0x28, 0xcb, 0x3a, 0x61, 0xd8, 0x47, 0x86, 0x49, 0x0f, 0xf9, 0x6c, 0xe1, 0x4b, 0x3d, 0xc8, 0x61,
+	0x9b, 0x60, 0xac, 0x7f, 0xe5, 0x04, 0xb9, 0xad, 0xca, 0x1b, 0x86, 0x34, 0x9b, 0x37, 0xda, 0x90,
+	0x93, 0xc5, 0x94, 0x70, 0xce, 0x62, 0xa3, 0x2e, 0xcb, 0x23, 0x95, 0x17, 0xa7, 0xb2, 0x5a, 0xa5,
+	0x2f, 0x27, 0x30, 0x96, 0xfc, 0x93, 0xbc, 0x44, 0x7e, 0x4e, 0x13, 0xad, 0x2c, 0x27, 0xc8, 0x6d,
+	0xd2, 0x86, 0x0d, 0x86, 0x43, 0x9a, 0x44, 0xc8, 0x7e, 0xe8, 0xf7, 0x39, 0x0a, 0xee, 0xae, 0xec,
+	0xd7, 0xdb, 0x8d, 0x60, 0xd2, 0x2d, 0x91, 0x6f, 0xc6, 0x14, 0xc5, 0xb7, 0xe9, 0x98, 0xf1, 0x53,
+	0x11, 0x32, 0xad, 0x37, 0x27, 0x98, 0x74, 0x93, 0x07, 0xd0, 0x2a, 0x5c, 0xcf, 0x92, 0x48, 0x29,
+	0xcb, 0x09, 0xca, 0x4e, 0x59, 0x55, 0x14, 0xd2, 0xf8, 0xfa, 0x30, 0x1c, 0x29, 0xe1, 0x34, 0x82,
+	0xdc, 0x96, 0xaa, 0x1e, 0x8f, 0x22, 0xa3, 0xea, 0xe6, 0x62, 0x55, 0xe7, 0x60, 0xff, 0x21, 0x90,
+	0x51, 0x41, 0x7d, 0xf5, 0xe8, 0x49, 0x60, 0xab, 0x84, 0xad, 0x9c, 0x3c, 0x4d, 0x0b, 0x6a, 0x86,
+	0xe2, 0xfd, 0x42, 0x1a, 0x73, 0x5a, 0x1f, 0xd8, 0x51, 0xfe, 0xdf, 0x4b, 0xb0, 0x99, 0xb1, 0x5a,
+	0x35, 0x7a, 0xd4, 0x2c, 0x51, 0x7a, 0x5f, 0xb2, 0x86, 0x4b, 0xf9, 0x72, 0xaa, 0xdb, 0x52, 0x72,
+	0x61, 0xc5, 0x48, 0xc7, 0xa8, 0x22, 0x33, 0xad, 0x61, 0xd4, 0x28, 0x0d, 0xa3, 0x7c, 0x97, 0xcb,
+	0xf6, 0x2e, 0xbf, 0x84, 0xa6, 0x19, 0x49, 0x37, 0xbc, 0x84, 0x6c, 0x38, 0x79, 0x22, 0x4f, 0x4f,
+	0x1f, 0x19, 0xc3, 0xe8, 0x2c, 0xbd, 0xc1, 0x2c, 0xb2, 0xd0, 0xfe, 0x73, 0xf8, 0x30, 0x63, 0xe6,
+	0x54, 0x55, 0x98, 0x75, 0xae, 0x44, 0x47, 0x6d, 0x06, 0x1d, 0x33, 0xce, 0xfd, 0x25, 0xec, 0x4c,
+	0x26, 0xab, 0x68, 0xed, 0x93, 0x19, 0x87, 0xde, 0x2b, 0x3a, 0x3b, 0xd9, 0x30, 0xfb, 0xd4, 0x1f,
+	0xfc, 0xe1, 0x40, 0xbd, 0x7b, 0x72, 0x4c, 0x9e, 0x42, 0x93, 0x26, 0x1c, 0x99, 0x50, 0x0f, 0x18,
+	0x32, 0xf9, 0xde, 0xf2, 0x6e, 0x17, 0x8e, 0xa9, 0x07, 0x96, 0xff, 0x01, 0xf9, 0x0e, 0x9a, 0x5a,
+	0xc1, 0x3a, 0x7c, 0x6f, 0x0e, 0x5a, 0x31, 0x72, 0x83, 0x5c, 0x0c, 0x87, 0xe9, 0xd5, 0xff, 0x91,
+	0xeb, 0x29, 0xac, 0x0f, 0x50, 0x1c, 0xc9, 0x53, 0xa9, 0x16, 0x38, 0xd9, 0x29, 0x02, 0x8a, 0x67,
+	0x99, 0xb7, 0x3d, 0xe1, 0x35, 0xe1, 0x5f, 0xc1, 0xc6, 0x00, 0xc5, 0x2b, 0xc4, 0xd7, 0xef, 0x16,
+	0xff, 0x35, 0x6c, 0x0e, 0x50, 0xbc, 0x48, 0x13, 0x71, 0xf9, 0x6e, 0x09, 0x8e, 0xa1, 0xa5, 0xef,
+	0x3b, 0xf3, 0xba, 0x20, 0xd5, 0x2f, 0x05, 0x6f, 0x77, 0x6a, 0xd9, 0x4e, 0xa5, 0x5b, 0xf4, 0xfe,
+	0xa9, 0x8e, 0xa0, 0xa5, 0x3b, 0x94, 0xa5, 0x72, 0x67, 0x60, 0xf5, 0xbe, 0x2a, 0xb2, 0x74, 0xd5,
+	0xc3, 0xf8, 0xbd, 0x52, 0xbc, 0x80, 0x35, 0xfb, 0xe9, 0x65, 0x6f, 0x69, 0xc6, 0xfb, 0xcd, 0xdb,
+	0x9b, 0xb7, 0x6c, 0xd2, 0xfd, 0x0a, 0x3b, 0x45, 0x45, 0xc5, 0x8d, 0x4b, 0xfc, 0x79, 0x37, 0x68,
+	0x71, 0xd9, 0x7b, 0xf7, 0x2b, 0x31, 0xe6, 0x07, 0x3f, 0x81, 0x37, 0x40, 0xf1, 0x72, 0xce, 0x2d,
+	0x6b, 0x95, 0x37, 0x7d, 0x03, 0x78, 0xb7, 0xe7, 0xac, 0x9a, 0xd4, 0x21, 0xdc, 0xd1, 0xed, 0x9d,
+	0x97, 0x7d, 0xf1, 0xac, 0x5f, 0xf4, 0x8b, 0x1f, 0x61, 0x4b, 0x69, 0xd3, 0x1e, 0x4c, 0xe4, 0xde,
+	0xf4, 0xa0, 0x29, 0xcd, 0x3f, 0xef, 0xee, 0x7c, 0x80, 0xce, 0x7b, 0xb1, 0xac, 0x46, 0xeb, 0xa7,
+	0xff, 0x0e, 0x00, 0xe9, 0x04, 0xe2, 0x06, 0x4e, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // APIClient is the client API for API service.
 //
@@ -258,13 +1178,22 @@ type APIClient interface {
 	GetDailyEvents(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResult, error)
 	GetWeeklyEvents(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResult, error)
 	GetMonthlyEvents(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResult, error)
+	CreateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookResult, error)
+	UpdateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookResult, error)
+	RemoveWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*WebhookResult, error)
+	GetWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*WebhookResult, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResult, error)
+	GetWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResult, error)
+	GetNotificationPreferences(ctx context.Context, in *PreferencesRequest, opts ...grpc.CallOption) (*PreferencesResult, error)
+	UpdateNotificationPreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*PreferencesResult, error)
+	GetReminderStatus(ctx context.Context, in *ReminderStatusRequest, opts ...grpc.CallOption) (*ReminderStatusResult, error)
 }
 
 type aPIClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
 	return &aPIClient{cc}
 }
 
@@ -322,6 +1251,87 @@ func (c *aPIClient) GetMonthlyEvents(ctx context.Context, in *GetRequest, opts .
 	return out, nil
 }
 
+func (c *aPIClient) CreateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookResult, error) {
+	out := new(WebhookResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/createWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookResult, error) {
+	out := new(WebhookResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/updateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RemoveWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*WebhookResult, error) {
+	out := new(WebhookResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/removeWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*WebhookResult, error) {
+	out := new(WebhookResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/getWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResult, error) {
+	out := new(ListWebhooksResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/listWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResult, error) {
+	out := new(WebhookDeliveriesResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/getWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetNotificationPreferences(ctx context.Context, in *PreferencesRequest, opts ...grpc.CallOption) (*PreferencesResult, error) {
+	out := new(PreferencesResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/getNotificationPreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateNotificationPreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*PreferencesResult, error) {
+	out := new(PreferencesResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/updateNotificationPreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetReminderStatus(ctx context.Context, in *ReminderStatusRequest, opts ...grpc.CallOption) (*ReminderStatusResult, error) {
+	out := new(ReminderStatusResult)
+	err := c.cc.Invoke(ctx, "/calendar.API/getReminderStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 type APIServer interface {
 	InsertEvent(context.Context, *Event) (*ChangeEventResult, error)
@@ -330,6 +1340,15 @@ type APIServer interface {
 	GetDailyEvents(context.Context, *GetRequest) (*GetResult, error)
 	GetWeeklyEvents(context.Context, *GetRequest) (*GetResult, error)
 	GetMonthlyEvents(context.Context, *GetRequest) (*GetResult, error)
+	CreateWebhook(context.Context, *WebhookSubscription) (*WebhookResult, error)
+	UpdateWebhook(context.Context, *WebhookSubscription) (*WebhookResult, error)
+	RemoveWebhook(context.Context, *WebhookRequest) (*WebhookResult, error)
+	GetWebhook(context.Context, *WebhookRequest) (*WebhookResult, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResult, error)
+	GetWebhookDeliveries(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesResult, error)
+	GetNotificationPreferences(context.Context, *PreferencesRequest) (*PreferencesResult, error)
+	UpdateNotificationPreferences(context.Context, *NotificationPreferences) (*PreferencesResult, error)
+	GetReminderStatus(context.Context, *ReminderStatusRequest) (*ReminderStatusResult, error)
 }
 
 // UnimplementedAPIServer can be embedded to have forward compatible implementations.
@@ -354,6 +1373,33 @@ func (*UnimplementedAPIServer) GetWeeklyEvents(ctx context.Context, req *GetRequ
 func (*UnimplementedAPIServer) GetMonthlyEvents(ctx context.Context, req *GetRequest) (*GetResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMonthlyEvents not implemented")
 }
+func (*UnimplementedAPIServer) CreateWebhook(ctx context.Context, req *WebhookSubscription) (*WebhookResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (*UnimplementedAPIServer) UpdateWebhook(ctx context.Context, req *WebhookSubscription) (*WebhookResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (*UnimplementedAPIServer) RemoveWebhook(ctx context.Context, req *WebhookRequest) (*WebhookResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (*UnimplementedAPIServer) GetWebhook(ctx context.Context, req *WebhookRequest) (*WebhookResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (*UnimplementedAPIServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedAPIServer) GetWebhookDeliveries(ctx context.Context, req *WebhookDeliveriesRequest) (*WebhookDeliveriesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
+func (*UnimplementedAPIServer) GetNotificationPreferences(ctx context.Context, req *PreferencesRequest) (*PreferencesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (*UnimplementedAPIServer) UpdateNotificationPreferences(ctx context.Context, req *NotificationPreferences) (*PreferencesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (*UnimplementedAPIServer) GetReminderStatus(ctx context.Context, req *ReminderStatusRequest) (*ReminderStatusResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReminderStatus not implemented")
+}
 
 func RegisterAPIServer(s *grpc.Server, srv APIServer) {
 	s.RegisterService(&_API_serviceDesc, srv)
@@ -467,6 +1513,168 @@ func _API_GetMonthlyEvents_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscription)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateWebhook(ctx, req.(*WebhookSubscription))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscription)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/UpdateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateWebhook(ctx, req.(*WebhookSubscription))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/RemoveWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RemoveWebhook(ctx, req.(*WebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/GetWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetWebhook(ctx, req.(*WebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/GetWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetWebhookDeliveries(ctx, req.(*WebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/GetNotificationPreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetNotificationPreferences(ctx, req.(*PreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationPreferences)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/UpdateNotificationPreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateNotificationPreferences(ctx, req.(*NotificationPreferences))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetReminderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReminderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetReminderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calendar.API/GetReminderStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetReminderStatus(ctx, req.(*ReminderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _API_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "calendar.API",
 	HandlerType: (*APIServer)(nil),
@@ -495,6 +1703,42 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "getMonthlyEvents",
 			Handler:    _API_GetMonthlyEvents_Handler,
 		},
+		{
+			MethodName: "createWebhook",
+			Handler:    _API_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "updateWebhook",
+			Handler:    _API_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "removeWebhook",
+			Handler:    _API_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "getWebhook",
+			Handler:    _API_GetWebhook_Handler,
+		},
+		{
+			MethodName: "listWebhooks",
+			Handler:    _API_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "getWebhookDeliveries",
+			Handler:    _API_GetWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "getNotificationPreferences",
+			Handler:    _API_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "updateNotificationPreferences",
+			Handler:    _API_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "getReminderStatus",
+			Handler:    _API_GetReminderStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "API.proto",
@@ -68,6 +68,7 @@ type Event struct {
 	Owner                string               `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
 	MailingDuration      int32                `protobuf:"varint,6,opt,name=mailingDuration,proto3" json:"mailingDuration,omitempty"`
 	EventDuration        *EventDuration       `protobuf:"bytes,7,opt,name=eventDuration,proto3" json:"eventDuration,omitempty"`
+	Urgent               bool                 `protobuf:"varint,8,opt,name=urgent,proto3" json:"urgent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -147,6 +148,13 @@ func (m *Event) GetEventDuration() *EventDuration {
 	return nil
 }
 
+func (m *Event) GetUrgent() bool {
+	if m != nil {
+		return m.Urgent
+	}
+	return false
+}
+
 type EventDuration struct {
 	Start                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=Start,proto3" json:"Start,omitempty"`
 	Stop                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=Stop,proto3" json:"Stop,omitempty"`
@@ -203,23 +211,24 @@ func init() {
 func init() { proto.RegisterFile("events.proto", fileDescriptor_8f22242cb04491f9) }
 
 var fileDescriptor_8f22242cb04491f9 = []byte{
-	// 287 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x90, 0x41, 0x4f, 0xc2, 0x40,
-	0x10, 0x85, 0x53, 0xa0, 0x15, 0xa6, 0x12, 0x92, 0x89, 0xd1, 0x0d, 0x17, 0x9b, 0x5e, 0xec, 0x69,
-	0x31, 0x68, 0xbc, 0x79, 0xc3, 0x83, 0x89, 0xa7, 0x02, 0x3f, 0x60, 0xa1, 0x63, 0xdd, 0xa4, 0xed,
-	0xd6, 0xed, 0xa2, 0x7f, 0xc2, 0x1f, 0x6d, 0x9c, 0x52, 0x22, 0x1c, 0xb8, 0xed, 0xbe, 0xf7, 0xcd,
-	0xe4, 0xbd, 0x81, 0x4b, 0xfa, 0xa2, 0xca, 0x35, 0xb2, 0xb6, 0xc6, 0x19, 0x1c, 0x6e, 0x55, 0x41,
-	0x55, 0xa6, 0xec, 0xf4, 0x36, 0x37, 0x26, 0x2f, 0x68, 0xc6, 0xfa, 0x66, 0xf7, 0x3e, 0x73, 0xba,
-	0xa4, 0xc6, 0xa9, 0xb2, 0x6e, 0xd1, 0xf8, 0x11, 0x46, 0x2f, 0x7f, 0xa3, 0x6f, 0xba, 0x71, 0x78,
-	0x07, 0x41, 0xbb, 0x47, 0x78, 0x51, 0x3f, 0x09, 0xe7, 0x13, 0xd9, 0x2d, 0x92, 0x0c, 0xa5, 0x7b,
-	0x3b, 0xfe, 0xe9, 0x81, 0xcf, 0x0a, 0x22, 0x0c, 0xd6, 0xeb, 0xd7, 0x85, 0xf0, 0x22, 0x2f, 0x19,
-	0xa5, 0xfc, 0xc6, 0x6b, 0x08, 0x3e, 0x48, 0x65, 0x64, 0x45, 0x8f, 0xd5, 0xfd, 0x0f, 0x9f, 0x60,
-	0x98, 0x29, 0x47, 0x2b, 0x5d, 0x92, 0xe8, 0x47, 0x5e, 0x12, 0xce, 0xa7, 0xb2, 0xcd, 0x27, 0xbb,
-	0x7c, 0x72, 0xd5, 0xe5, 0x4b, 0x0f, 0x2c, 0x46, 0x10, 0x66, 0xd4, 0x6c, 0xad, 0xae, 0x9d, 0x36,
-	0x95, 0x18, 0xf0, 0xd2, 0xff, 0x12, 0x5e, 0x81, 0x6f, 0xbe, 0x2b, 0xb2, 0xc2, 0x67, 0xaf, 0xfd,
-	0x60, 0x02, 0x93, 0x52, 0xe9, 0x42, 0x57, 0xf9, 0x62, 0x67, 0x15, 0xcf, 0x06, 0x91, 0x97, 0xf8,
-	0xe9, 0xa9, 0x8c, 0xcf, 0x30, 0xe6, 0x66, 0x07, 0xee, 0x82, 0xe3, 0xdd, 0x9c, 0xf4, 0xef, 0xec,
-	0xf4, 0x98, 0x8e, 0x3f, 0x61, 0x7c, 0xe4, 0xe3, 0x3d, 0xf8, 0x4b, 0xa7, 0xac, 0xe3, 0xb3, 0x9c,
-	0xaf, 0xd9, 0x82, 0x28, 0x61, 0xb0, 0x74, 0xa6, 0xe6, 0x8b, 0x9d, 0x1f, 0x60, 0x6e, 0x13, 0xb0,
-	0xf3, 0xf0, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x8b, 0xc5, 0xb8, 0x0e, 0xf9, 0x01, 0x00, 0x00,
+	// 297 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0xc1, 0x4e, 0xf2, 0x40,
+	0x14, 0x85, 0x33, 0x40, 0xfb, 0x97, 0xcb, 0x4f, 0x48, 0x6e, 0x8c, 0x4e, 0xd8, 0xd8, 0xb0, 0xb1,
+	0xab, 0xc1, 0xa0, 0x71, 0xe7, 0x0e, 0x17, 0x26, 0xae, 0x0a, 0x3c, 0xc0, 0x40, 0xaf, 0x75, 0x12,
+	0xda, 0xa9, 0xd3, 0x41, 0x5f, 0xc8, 0x07, 0x35, 0xdc, 0x52, 0x22, 0x6c, 0xd8, 0xcd, 0x9c, 0xf3,
+	0x9d, 0x9b, 0x73, 0xe0, 0x3f, 0x7d, 0x51, 0xe9, 0x6b, 0x55, 0x39, 0xeb, 0x2d, 0x46, 0x1b, 0xbd,
+	0xa5, 0x32, 0xd3, 0x6e, 0x7c, 0x9b, 0x5b, 0x9b, 0x6f, 0x69, 0xca, 0xfa, 0x7a, 0xf7, 0x3e, 0xf5,
+	0xa6, 0xa0, 0xda, 0xeb, 0xa2, 0x6a, 0xd0, 0xc9, 0x23, 0xf4, 0x5f, 0xf6, 0xd1, 0x37, 0x53, 0x7b,
+	0xbc, 0x83, 0xb0, 0xb9, 0x23, 0x45, 0xdc, 0x4d, 0x06, 0xb3, 0x91, 0x6a, 0x0f, 0x29, 0x86, 0xd2,
+	0x83, 0x3d, 0xf9, 0xe9, 0x40, 0xc0, 0x0a, 0x22, 0xf4, 0x56, 0xab, 0xd7, 0xb9, 0x14, 0xb1, 0x48,
+	0xfa, 0x29, 0xbf, 0xf1, 0x1a, 0xc2, 0x0f, 0xd2, 0x19, 0x39, 0xd9, 0x61, 0xf5, 0xf0, 0xc3, 0x27,
+	0x88, 0x32, 0xed, 0x69, 0x69, 0x0a, 0x92, 0xdd, 0x58, 0x24, 0x83, 0xd9, 0x58, 0x35, 0xfd, 0x54,
+	0xdb, 0x4f, 0x2d, 0xdb, 0x7e, 0xe9, 0x91, 0xc5, 0x18, 0x06, 0x19, 0xd5, 0x1b, 0x67, 0x2a, 0x6f,
+	0x6c, 0x29, 0x7b, 0x7c, 0xf4, 0xaf, 0x84, 0x57, 0x10, 0xd8, 0xef, 0x92, 0x9c, 0x0c, 0xd8, 0x6b,
+	0x3e, 0x98, 0xc0, 0xa8, 0xd0, 0x66, 0x6b, 0xca, 0x7c, 0xbe, 0x73, 0x9a, 0xb3, 0x61, 0x2c, 0x92,
+	0x20, 0x3d, 0x97, 0xf1, 0x19, 0x86, 0xbc, 0xec, 0xc8, 0xfd, 0xe3, 0x7a, 0x37, 0x67, 0xfb, 0x5b,
+	0x3b, 0x3d, 0xa5, 0xf7, 0x83, 0x77, 0x2e, 0xa7, 0xd2, 0xcb, 0x28, 0x16, 0x49, 0x94, 0x1e, 0x7e,
+	0x93, 0x4f, 0x18, 0x9e, 0xe4, 0xf0, 0x1e, 0x82, 0x85, 0xd7, 0xce, 0x4b, 0x71, 0x71, 0x7e, 0x03,
+	0xa2, 0x82, 0xde, 0xc2, 0xdb, 0x4a, 0x76, 0x2e, 0x06, 0x98, 0x5b, 0x87, 0xec, 0x3c, 0xfc, 0x0e,
+	0x00, 0x6d, 0x91, 0x2a, 0x1f, 0x11, 0x02, 0x00, 0x00,
 }
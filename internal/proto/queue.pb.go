@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: queue.proto
+
+package calendar
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Envelope struct {
+	Type                 string               `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version              int32                `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	MessageId            string               `protobuf:"bytes,3,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	OccurredAt           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	Event                *Event               `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	Reminder             *Reminder            `protobuf:"bytes,6,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Envelope) Reset()         { *m = Envelope{} }
+func (m *Envelope) String() string { return proto.CompactTextString(m) }
+func (*Envelope) ProtoMessage()    {}
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96e4d7d76a734cd8, []int{0}
+}
+
+func (m *Envelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Envelope.Unmarshal(m, b)
+}
+func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Envelope.Marshal(b, m, deterministic)
+}
+func (m *Envelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Envelope.Merge(m, src)
+}
+func (m *Envelope) XXX_Size() int {
+	return xxx_messageInfo_Envelope.Size(m)
+}
+func (m *Envelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_Envelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Envelope proto.InternalMessageInfo
+
+func (m *Envelope) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Envelope) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Envelope) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *Envelope) GetOccurredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+func (m *Envelope) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *Envelope) GetReminder() *Reminder {
+	if m != nil {
+		return m.Reminder
+	}
+	return nil
+}
+
+type Reminder struct {
+	Occurrence           *timestamp.Timestamp `protobuf:"bytes,1,opt,name=occurrence,proto3" json:"occurrence,omitempty"`
+	Offset               int32                `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Reminder) Reset()         { *m = Reminder{} }
+func (m *Reminder) String() string { return proto.CompactTextString(m) }
+func (*Reminder) ProtoMessage()    {}
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96e4d7d76a734cd8, []int{1}
+}
+
+func (m *Reminder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reminder.Unmarshal(m, b)
+}
+func (m *Reminder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reminder.Marshal(b, m, deterministic)
+}
+func (m *Reminder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reminder.Merge(m, src)
+}
+func (m *Reminder) XXX_Size() int {
+	return xxx_messageInfo_Reminder.Size(m)
+}
+func (m *Reminder) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reminder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reminder proto.InternalMessageInfo
+
+func (m *Reminder) GetOccurrence() *timestamp.Timestamp {
+	if m != nil {
+		return m.Occurrence
+	}
+	return nil
+}
+
+func (m *Reminder) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Envelope)(nil), "calendar.Envelope")
+	proto.RegisterType((*Reminder)(nil), "calendar.Reminder")
+}
+
+func init() { proto.RegisterFile("queue.proto", fileDescriptor_96e4d7d76a734cd8) }
+
+var fileDescriptor_96e4d7d76a734cd8 = []byte{
+	// 254 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x41, 0x4b, 0xf4, 0x30,
+	0x10, 0x86, 0xe9, 0xf7, 0x6d, 0x6b, 0x77, 0x56, 0x10, 0xe6, 0x20, 0xa1, 0x08, 0x96, 0x05, 0xa1,
+	0xa7, 0x2c, 0xe8, 0xcd, 0x9b, 0x87, 0x3d, 0x78, 0x0d, 0x9e, 0x85, 0x6e, 0x3a, 0x2d, 0x85, 0x36,
+	0xa9, 0x49, 0x5a, 0xf0, 0x27, 0xfb, 0x2f, 0xc4, 0xa4, 0x71, 0xf7, 0xe8, 0x2d, 0xf3, 0xce, 0xf3,
+	0xc2, 0x33, 0x81, 0xdd, 0xc7, 0x4c, 0x33, 0xf1, 0xc9, 0x68, 0xa7, 0x31, 0x97, 0xf5, 0x40, 0xaa,
+	0xa9, 0x4d, 0x71, 0xdf, 0x69, 0xdd, 0x0d, 0x74, 0xf0, 0xf9, 0x69, 0x6e, 0x0f, 0xae, 0x1f, 0xc9,
+	0xba, 0x7a, 0x9c, 0x02, 0x5a, 0x5c, 0xd3, 0x42, 0xca, 0xd9, 0x30, 0xed, 0xbf, 0x12, 0xc8, 0x8f,
+	0x6a, 0xa1, 0x41, 0x4f, 0x84, 0x08, 0x1b, 0xf7, 0x39, 0x11, 0x4b, 0xca, 0xa4, 0xda, 0x0a, 0xff,
+	0x46, 0x06, 0x57, 0x0b, 0x19, 0xdb, 0x6b, 0xc5, 0xfe, 0x95, 0x49, 0x95, 0x8a, 0x38, 0xe2, 0x1d,
+	0x6c, 0x47, 0xb2, 0xb6, 0xee, 0xe8, 0xb5, 0x61, 0xff, 0x7d, 0xe5, 0x1c, 0xe0, 0x33, 0x80, 0x96,
+	0x72, 0x36, 0x86, 0x9a, 0x17, 0xc7, 0x36, 0x65, 0x52, 0xed, 0x1e, 0x0b, 0x1e, 0xe4, 0x78, 0x94,
+	0xe3, 0x6f, 0x51, 0x4e, 0x5c, 0xd0, 0xf8, 0x00, 0xa9, 0x97, 0x64, 0xa9, 0xaf, 0xdd, 0xf0, 0x78,
+	0x1d, 0x3f, 0xfe, 0xc4, 0x22, 0x6c, 0x91, 0x43, 0x6e, 0x68, 0xec, 0x55, 0x43, 0x86, 0x65, 0x9e,
+	0xc4, 0x33, 0x29, 0xd6, 0x8d, 0xf8, 0x65, 0xf6, 0xef, 0x90, 0xc7, 0xf4, 0x42, 0x4f, 0xc9, 0x70,
+	0xf0, 0xdf, 0xf4, 0x94, 0x24, 0xbc, 0x85, 0x4c, 0xb7, 0xad, 0x25, 0xb7, 0xfe, 0xc8, 0x3a, 0x9d,
+	0x32, 0xdf, 0x7b, 0xfa, 0x1e, 0x00, 0x28, 0xe2, 0xad, 0x6f, 0x9a, 0x01, 0x00, 0x00,
+}
@@ -76,6 +76,7 @@ func PBEventToPSQLEvent(event *pb.Event) (structs.Event, error) {
 		MailingDuration:    event.MailingDuration,
 		EventDurationStart: time.Date(dtStart.Year(), dtStart.Month(), dtStart.Day(), dtStart.Hour(), dtStart.Minute(), dtStart.Second(), dtStart.Nanosecond(), dtStart.Location()),
 		EventDurationStop:  time.Date(dtStop.Year(), dtStop.Month(), dtStop.Day(), dtStop.Hour(), dtStop.Minute(), dtStop.Second(), dtStop.Nanosecond(), dtStop.Location()),
+		Urgent:             event.Urgent,
 	}
 
 	return psqlEvent, nil
@@ -103,6 +104,7 @@ func PSQLEventToPBEvent(event structs.Event) (*pb.Event, error) {
 		Owner:           event.Owner,
 		MailingDuration: event.MailingDuration,
 		EventDuration:   &pb.EventDuration{Start: dtStart, Stop: dtStop},
+		Urgent:          event.Urgent,
 	}
 
 	return &pbEvent, nil
@@ -155,7 +157,7 @@ func (s *API) InsertEvent(ctx context.Context, event *pb.Event) (*pb.ChangeEvent
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
 
-	result, err := s.psql.InsertEvent(psqlEvent)
+	result, err := s.psql.InsertEvent(ctx, psqlEvent)
 	if err != nil {
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
@@ -174,7 +176,7 @@ func (s *API) UpdateEvent(ctx context.Context, req *pb.ChangeEventRequest) (*pb.
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
 
-	result, err := s.psql.UpdateEvent(psqlChangeRequest)
+	result, err := s.psql.UpdateEvent(ctx, psqlChangeRequest)
 	if err != nil {
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
@@ -194,7 +196,7 @@ func (s *API) RemoveEvent(ctx context.Context, req *pb.ChangeEventRequest) (*pb.
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
 
-	result, err := s.psql.RemoveEvent(psqlChangeRequest)
+	result, err := s.psql.RemoveEvent(ctx, psqlChangeRequest)
 	if err != nil {
 		return &pb.ChangeEventResult{Error: err.Error(), Result: false}, nil
 	}
@@ -214,7 +216,7 @@ func (s *API) GetDailyEvents(ctx context.Context, req *pb.GetRequest) (*pb.GetRe
 	dateDayStart := time.Date(new_date.Year(), new_date.Month(), new_date.Day(), 0, 0, 0, 0, new_date.Location())
 	dateDayEnd := dateDayStart.AddDate(0, 0, 1)
 
-	psqlEvents, err := s.psql.GetEvents(dateDayStart, dateDayEnd)
+	psqlEvents, err := s.psql.GetEvents(ctx, dateDayStart, dateDayEnd)
 	if err != nil {
 		return &pb.GetResult{
 			Error:  err.Error(),
@@ -239,7 +241,7 @@ func (s *API) GetWeeklyEvents(ctx context.Context, req *pb.GetRequest) (*pb.GetR
 	dateWeekStart := time.Date(new_date.Year(), new_date.Month(), new_date.Day(), 0, 0, 0, 0, new_date.Location())
 	dateWeekEnd := dateWeekStart.AddDate(0, 0, 7)
 
-	psqlEvents, err := s.psql.GetEvents(dateWeekStart, dateWeekEnd)
+	psqlEvents, err := s.psql.GetEvents(ctx, dateWeekStart, dateWeekEnd)
 	if err != nil {
 		return &pb.GetResult{
 			Error:  err.Error(),
@@ -264,7 +266,7 @@ func (s *API) GetMonthlyEvents(ctx context.Context, req *pb.GetRequest) (*pb.Get
 	dateMonthStart := time.Date(new_date.Year(), new_date.Month(), new_date.Day(), 0, 0, 0, 0, new_date.Location())
 	dateMonthEnd := dateMonthStart.AddDate(0, 1, 0)
 
-	psqlEvents, err := s.psql.GetEvents(dateMonthStart, dateMonthEnd)
+	psqlEvents, err := s.psql.GetEvents(ctx, dateMonthStart, dateMonthEnd)
 	if err != nil {
 		return &pb.GetResult{
 			Error:  err.Error(),
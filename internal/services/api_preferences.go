@@ -0,0 +1,90 @@
+package services
+
+import (
+	"calendar/internal/notification"
+	pb "calendar/internal/proto"
+	"calendar/internal/structs"
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	"time"
+)
+
+//Функции мутации типов
+
+func PBPreferencesToPreferences(prefs *pb.NotificationPreferences) structs.NotificationPreferences {
+	return structs.NotificationPreferences{
+		Owner:           prefs.Owner,
+		Channels:        prefs.Channels,
+		Email:           prefs.Email,
+		WebhookURL:      prefs.WebhookUrl,
+		Locale:          prefs.Locale,
+		TimeZone:        prefs.TimeZone,
+		ReminderOffsets: prefs.ReminderOffsets,
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		DailyCap:        prefs.DailyCap,
+	}
+}
+
+func PreferencesToPBPreferences(prefs structs.NotificationPreferences) (*pb.NotificationPreferences, error) {
+	updatedAt, err := ptypes.TimestampProto(prefs.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.NotificationPreferences{
+		Owner:           prefs.Owner,
+		Channels:        prefs.Channels,
+		Email:           prefs.Email,
+		WebhookUrl:      prefs.WebhookURL,
+		Locale:          prefs.Locale,
+		TimeZone:        prefs.TimeZone,
+		ReminderOffsets: prefs.ReminderOffsets,
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		DailyCap:        prefs.DailyCap,
+		UpdatedAt:       updatedAt,
+	}, nil
+}
+
+//Настройки оповещений
+
+//без сохраненных настроек возвращаются пустые
+func (s *API) GetNotificationPreferences(ctx context.Context, req *pb.PreferencesRequest) (*pb.PreferencesResult, error) {
+
+	prefs, ok, err := s.psql.GetNotificationPreferences(ctx, req.Owner)
+	if err != nil {
+		return &pb.PreferencesResult{Error: err.Error()}, nil
+	}
+	if !ok {
+		return &pb.PreferencesResult{Error: "nil", Preferences: &pb.NotificationPreferences{Owner: req.Owner}}, nil
+	}
+
+	pbPrefs, err := PreferencesToPBPreferences(prefs)
+	if err != nil {
+		return &pb.PreferencesResult{Error: err.Error()}, nil
+	}
+	return &pb.PreferencesResult{Error: "nil", Preferences: pbPrefs}, nil
+}
+
+//UpdateNotificationPreferences проверяет и целиком заменяет настройки владельца
+func (s *API) UpdateNotificationPreferences(ctx context.Context, req *pb.NotificationPreferences) (*pb.PreferencesResult, error) {
+
+	prefs := PBPreferencesToPreferences(req)
+	err := notification.ValidatePreferences(prefs)
+	if err != nil {
+		return &pb.PreferencesResult{Error: err.Error()}, nil
+	}
+	prefs.UpdatedAt = time.Now().UTC()
+
+	err = s.psql.SaveNotificationPreferences(ctx, prefs)
+	if err != nil {
+		return &pb.PreferencesResult{Error: err.Error()}, nil
+	}
+
+	pbPrefs, err := PreferencesToPBPreferences(prefs)
+	if err != nil {
+		return &pb.PreferencesResult{Error: err.Error()}, nil
+	}
+	return &pb.PreferencesResult{Error: "nil", Preferences: pbPrefs}, nil
+}
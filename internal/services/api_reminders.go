@@ -0,0 +1,62 @@
+package services
+
+import (
+	pb "calendar/internal/proto"
+	"calendar/internal/structs"
+	"context"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const defaultReminderDeliveriesLimit = 100
+
+//Функции мутации типов
+
+func ReminderDeliveryToPBReminderDelivery(delivery structs.ReminderDelivery) (*pb.ReminderDelivery, error) {
+	attemptedAt, err := ptypes.TimestampProto(delivery.AttemptedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	pbDelivery := pb.ReminderDelivery{
+		Id:          delivery.Id,
+		EventUUID:   delivery.EventUUID,
+		Owner:       delivery.Owner,
+		Channel:     delivery.Channel,
+		Status:      delivery.Status,
+		AttemptedAt: attemptedAt,
+	}
+	if delivery.Error != nil {
+		pbDelivery.Error = *delivery.Error
+	}
+	if delivery.DeferredTo != nil {
+		pbDelivery.DeferredTo, err = ptypes.TimestampProto(*delivery.DeferredTo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pbDelivery, nil
+}
+
+//GetReminderStatus возвращает журнал доставок напоминаний о событии по каналам, новые первыми
+func (s *API) GetReminderStatus(ctx context.Context, req *pb.ReminderStatusRequest) (*pb.ReminderStatusResult, error) {
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultReminderDeliveriesLimit
+	}
+
+	deliveries, err := s.psql.GetReminderDeliveries(ctx, req.EventUUID, limit)
+	if err != nil {
+		return &pb.ReminderStatusResult{Error: err.Error()}, nil
+	}
+
+	pbDeliveries := make([]*pb.ReminderDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		pbDelivery, err := ReminderDeliveryToPBReminderDelivery(delivery)
+		if err != nil {
+			return &pb.ReminderStatusResult{Error: err.Error()}, nil
+		}
+		pbDeliveries = append(pbDeliveries, pbDelivery)
+	}
+	return &pb.ReminderStatusResult{Error: "nil", Deliveries: pbDeliveries}, nil
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	pb "calendar/internal/proto"
+	"calendar/internal/structs"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/golang/protobuf/ptypes"
+	"net/url"
+	"time"
+)
+
+const defaultWebhookDeliveriesLimit = 100
+
+//Функции мутации типов
+
+func PBWebhookToWebhook(sub *pb.WebhookSubscription) (structs.WebhookSubscription, error) {
+	u, err := url.Parse(sub.Url)
+	if err != nil {
+		return structs.WebhookSubscription{}, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return structs.WebhookSubscription{}, errors.New("webhook url must be http or https")
+	}
+
+	return structs.WebhookSubscription{
+		UUID:       sub.UUID,
+		Owner:      sub.Owner,
+		URL:        sub.Url,
+		Secret:     sub.Secret,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+	}, nil
+}
+
+//WebhookToPBWebhook не возвращает секрет, он показывается только при создании подписки
+func WebhookToPBWebhook(sub structs.WebhookSubscription) (*pb.WebhookSubscription, error) {
+	createdAt, err := ptypes.TimestampProto(sub.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.WebhookSubscription{
+		UUID:       sub.UUID,
+		Owner:      sub.Owner,
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+func WebhookDeliveryToPBWebhookDelivery(delivery structs.WebhookDelivery) (*pb.WebhookDelivery, error) {
+	createdAt, err := ptypes.TimestampProto(delivery.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	nextAttemptAt, err := ptypes.TimestampProto(delivery.NextAttemptAt)
+	if err != nil {
+		return nil, err
+	}
+
+	pbDelivery := pb.WebhookDelivery{
+		Id:            delivery.Id,
+		MessageId:     delivery.MessageId,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		CreatedAt:     createdAt,
+		NextAttemptAt: nextAttemptAt,
+	}
+	if delivery.ResponseCode != nil {
+		pbDelivery.ResponseCode = *delivery.ResponseCode
+	}
+	if delivery.Error != nil {
+		pbDelivery.Error = *delivery.Error
+	}
+	if delivery.LastAttemptAt != nil {
+		pbDelivery.LastAttemptAt, err = ptypes.TimestampProto(*delivery.LastAttemptAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pbDelivery, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+//CRUD подписок на вебхуки
+
+//секрет возвращается в ответе только здесь
+func (s *API) CreateWebhook(ctx context.Context, req *pb.WebhookSubscription) (*pb.WebhookResult, error) {
+
+	sub, err := PBWebhookToWebhook(req)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	if sub.UUID == "" {
+		sub.UUID = structs.NewMessageId()
+	}
+	if sub.Secret == "" {
+		sub.Secret, err = newWebhookSecret()
+		if err != nil {
+			return &pb.WebhookResult{Error: err.Error()}, nil
+		}
+	}
+	sub.CreatedAt = time.Now().UTC()
+
+	err = s.psql.InsertWebhook(ctx, sub)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+
+	pbSub, err := WebhookToPBWebhook(sub)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	pbSub.Secret = sub.Secret
+	return &pb.WebhookResult{Error: "nil", Subscription: pbSub}, nil
+}
+
+//UpdateWebhook заменяет адрес, фильтр и активность подписки. Пустой секрет оставляет старый
+func (s *API) UpdateWebhook(ctx context.Context, req *pb.WebhookSubscription) (*pb.WebhookResult, error) {
+
+	sub, err := PBWebhookToWebhook(req)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+
+	current, err := s.psql.GetWebhook(ctx, sub.UUID)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	if sub.Secret == "" {
+		sub.Secret = current.Secret
+	}
+	sub.CreatedAt = current.CreatedAt
+
+	err = s.psql.UpdateWebhook(ctx, sub)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+
+	pbSub, err := WebhookToPBWebhook(sub)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	return &pb.WebhookResult{Error: "nil", Subscription: pbSub}, nil
+}
+
+func (s *API) RemoveWebhook(ctx context.Context, req *pb.WebhookRequest) (*pb.WebhookResult, error) {
+
+	err := s.psql.RemoveWebhook(ctx, req.UUID)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	return &pb.WebhookResult{Error: "nil"}, nil
+}
+
+func (s *API) GetWebhook(ctx context.Context, req *pb.WebhookRequest) (*pb.WebhookResult, error) {
+
+	sub, err := s.psql.GetWebhook(ctx, req.UUID)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+
+	pbSub, err := WebhookToPBWebhook(sub)
+	if err != nil {
+		return &pb.WebhookResult{Error: err.Error()}, nil
+	}
+	return &pb.WebhookResult{Error: "nil", Subscription: pbSub}, nil
+}
+
+func (s *API) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResult, error) {
+
+	subs, err := s.psql.GetWebhooks(ctx, req.Owner)
+	if err != nil {
+		return &pb.ListWebhooksResult{Error: err.Error()}, nil
+	}
+
+	pbSubs := make([]*pb.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		pbSub, err := WebhookToPBWebhook(sub)
+		if err != nil {
+			return &pb.ListWebhooksResult{Error: err.Error()}, nil
+		}
+		pbSubs = append(pbSubs, pbSub)
+	}
+	return &pb.ListWebhooksResult{Error: "nil", Subscriptions: pbSubs}, nil
+}
+
+//GetWebhookDeliveries возвращает журнал доставок подписки, новые первыми
+func (s *API) GetWebhookDeliveries(ctx context.Context, req *pb.WebhookDeliveriesRequest) (*pb.WebhookDeliveriesResult, error) {
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+
+	deliveries, err := s.psql.GetWebhookDeliveries(ctx, req.SubscriptionUUID, limit)
+	if err != nil {
+		return &pb.WebhookDeliveriesResult{Error: err.Error()}, nil
+	}
+
+	pbDeliveries := make([]*pb.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		pbDelivery, err := WebhookDeliveryToPBWebhookDelivery(delivery)
+		if err != nil {
+			return &pb.WebhookDeliveriesResult{Error: err.Error()}, nil
+		}
+		pbDeliveries = append(pbDeliveries, pbDelivery)
+	}
+	return &pb.WebhookDeliveriesResult{Error: "nil", Deliveries: pbDeliveries}, nil
+}
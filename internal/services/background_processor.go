@@ -1,57 +1,136 @@
 package services
 
 import (
+	"calendar/internal/config"
 	"calendar/internal/interfaces/postgres"
-	"calendar/internal/interfaces/rabbitmq"
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/lifecycle"
+	"calendar/internal/structs"
+	"calendar/internal/tracing"
+	"context"
+	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type BackgroundProcessor struct {
-	RabbitMQ rabbitmq.RabbitMQ
-	PSQL     postgres.PSQL
-	Logger   *zap.Logger
+	Publisher queue.Publisher
+	PSQL      postgres.PSQL
+	Logger    *zap.Logger
+	Interval  time.Duration //как часто проверять базу на наступившие напоминания, меняется через SetInterval
+	interval  int64
+	lastTick  int64 //время конца последней проверки в UnixNano
+
+	mu           sync.Mutex
+	ownerOffsets map[string][]int32
+}
+
+//SetInterval меняет интервал проверки, новое значение действует со следующей проверки
+func (bp *BackgroundProcessor) SetInterval(interval time.Duration) {
+	atomic.StoreInt64(&bp.interval, int64(interval))
+}
+
+//SetOwnerOffsets задает смещения напоминаний из notification.owners для владельцев без настроек в базе
+func (bp *BackgroundProcessor) SetOwnerOffsets(owners map[string]config.OwnerConfig) {
+	offsets := make(map[string][]int32)
+	for owner, prefs := range owners {
+		if len(prefs.ReminderOffsets) > 0 {
+			offsets[strings.ToLower(owner)] = prefs.ReminderOffsets
+		}
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	bp.ownerOffsets = offsets
+}
+
+func (bp *BackgroundProcessor) offsets() map[string][]int32 {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	return bp.ownerOffsets
 }
 
-func (bp *BackgroundProcessor) Run() error {
+//Alive проверяет, что цикл проверки напоминаний не завис: последняя проверка была не раньше трех интервалов назад
+func (bp *BackgroundProcessor) Alive(ctx context.Context) error {
+	last := atomic.LoadInt64(&bp.lastTick)
+	if last == 0 {
+		return errors.New("scheduler has not run yet")
+	}
+	since := time.Since(time.Unix(0, last))
+	if limit := 3 * time.Duration(atomic.LoadInt64(&bp.interval)); since > limit {
+		return fmt.Errorf("last scheduler tick was %v ago, expected within %v", since.Round(time.Second), limit)
+	}
+	return nil
+}
 
-	go func() {
+//Run проверяет напоминания раз в интервал до отмены ctx. Начатая проверка доводится до конца, поэтому запрос к базе выполняется без отмены
+func (bp *BackgroundProcessor) Run(ctx context.Context) error {
+	bp.SetInterval(bp.Interval)
 
-		start := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), time.Now().Hour(), time.Now().Minute(), time.Now().Second(), time.Now().Nanosecond(), time.Now().Location())
-		stop := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), time.Now().Hour(), time.Now().Minute(), time.Now().Second(), time.Now().Nanosecond(), time.Now().Location())
+	start := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), time.Now().Hour(), time.Now().Minute(), time.Now().Second(), time.Now().Nanosecond(), time.Now().Location())
+	stop := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), time.Now().Hour(), time.Now().Minute(), time.Now().Second(), time.Now().Nanosecond(), time.Now().Location())
 
-		for {
+	for {
 
-			bp.Logger.Info(fmt.Sprintf("Checking %v  --  %v", start, stop))
-			//чекаем базу на наличие сообщений для рассылки
-			events, err := bp.PSQL.GetPublishEvents(start, stop)
-			if err != nil {
-				bp.Logger.Error(err.Error())
-			} else {
-				if events != nil {
-					for _, event := range events {
+		bp.Logger.Info(fmt.Sprintf("Checking %v  --  %v", start, stop))
+		//при ошибке следующее окно начинается с самого раннего неотправленного напоминания
+		next := stop
+		//чекаем базу на наличие сообщений для рассылки
+		reminders, err := bp.PSQL.GetPublishReminders(context.Background(), start, stop, bp.offsets())
+		if err != nil {
+			bp.Logger.Error(err.Error())
+			next = start
+		} else {
+			remindersScanned.Add(float64(len(reminders)))
+			for _, reminder := range reminders {
 
-						bp.Logger.Info(fmt.Sprintf("Publish to RMQ %v", event))
+				bp.Logger.Info(fmt.Sprintf("Publish to queue %v", reminder.Event))
 
-						//постим в RabbitMQ
-						err = bp.RabbitMQ.Publish(event)
-						if err != nil {
-							bp.Logger.Error(err.Error())
-						}
+				//постим в очередь
+				err = bp.publish(reminder)
+				if err != nil {
+					bp.Logger.Error(err.Error())
+					if at := reminder.Reminder.NotifyAt(); at.Before(next) {
+						next = at
 					}
+				} else {
+					remindersPublished.Inc()
 				}
 			}
+		}
+		schedulerTicks.Inc()
+		schedulerLastTick.SetToCurrentTime()
+		atomic.StoreInt64(&bp.lastTick, time.Now().UnixNano())
 
-			//смещаем интервал времени
-			start = stop
-			stop = stop.Add(time.Second * 10)
-			bp.Logger.Info(fmt.Sprintf("Sleep %v", time.Second*10))
-			//повторяем раз в 10 сек
-			time.Sleep(10 * time.Second)
+		//смещаем интервал времени
+		interval := time.Duration(atomic.LoadInt64(&bp.interval))
+		start = next
+		stop = stop.Add(interval)
+		bp.Logger.Info(fmt.Sprintf("Sleep %v", interval))
+		//повторяем раз в interval
+		if !lifecycle.Sleep(ctx, interval) {
+			return nil
 		}
+	}
+}
 
-	}()
+var tracer = tracing.Tracer("scheduler")
 
-	return nil
+//спан продолжает трассу запроса, который создал или изменил событие
+func (bp *BackgroundProcessor) publish(reminder postgres.PSQLReminder) error {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), tracing.SpanContext(reminder.TraceContext))
+	ctx, span := tracer.Start(ctx, "publish reminder", trace.WithAttributes(tracing.EventUUID.String(reminder.Event.UUID)))
+	defer span.End()
+
+	err := bp.Publisher.Publish(ctx, structs.NewReminderEnvelope(reminder.Event, reminder.Reminder))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
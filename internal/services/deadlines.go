@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Deadlines задает срок выполнения запросов к API, срок клиента сохраняется, если он раньше
+type Deadlines struct {
+	mu       sync.RWMutex
+	methods  map[string]bool
+	timeout  time.Duration
+	timeouts map[string]time.Duration
+}
+
+//SetMethods запоминает методы зарегистрированных сервисов, чтобы опечатка в grpc.timeouts была ошибкой, а не молча игнорировалась
+func (d *Deadlines) SetMethods(services map[string]grpc.ServiceInfo) {
+	methods := make(map[string]bool)
+	for _, service := range services {
+		for _, method := range service.Methods {
+			methods[strings.ToLower(method.Name)] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.methods = methods
+}
+
+//Set меняет сроки, новые значения действуют со следующего запроса
+func (d *Deadlines) Set(timeout time.Duration, timeouts map[string]time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	byMethod := make(map[string]time.Duration)
+	for method, t := range timeouts {
+		method = strings.ToLower(method)
+		if d.methods != nil && !d.methods[method] {
+			return fmt.Errorf("grpc.timeouts: unknown method %v", method)
+		}
+		byMethod[method] = t
+	}
+	d.timeout = timeout
+	d.timeouts = byMethod
+	return nil
+}
+
+//Timeout возвращает срок метода по полному имени /calendar.API/getMonthlyEvents
+func (d *Deadlines) Timeout(fullMethod string) time.Duration {
+	method := strings.ToLower(fullMethod[strings.LastIndex(fullMethod, "/")+1:])
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if t, ok := d.timeouts[method]; ok {
+		return t
+	}
+	return d.timeout
+}
+
+//обработчики возвращают ошибку базы в поле error ответа, поэтому истекший срок заменяет только неудачный ответ кодом DeadlineExceeded
+func (d *Deadlines) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if timeout := d.Timeout(info.FullMethod); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	resp, err := handler(ctx, req)
+	if ctx.Err() != nil && failed(resp, err) {
+		return nil, ContextError(ctx.Err())
+	}
+	return resp, err
+}
+
+//ответ, успевший выполниться до истечения срока, отдается клиенту как есть
+func failed(resp interface{}, err error) bool {
+	if err != nil {
+		return true
+	}
+	if result, ok := resp.(interface{ GetError() string }); ok {
+		return result.GetError() != "nil"
+	}
+	return false
+}
+
+//ContextError переводит ошибку контекста в статус gRPC. Остальные ошибки возвращаются без изменений
+func ContextError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	pb "calendar/internal/proto"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    interface{}
+		err     error
+		wantErr codes.Code
+	}{
+		{name: "success after deadline", resp: &pb.ChangeEventResult{Error: "nil", Result: true}, wantErr: codes.OK},
+		{name: "failed response after deadline", resp: &pb.ChangeEventResult{Error: "context deadline exceeded"}, wantErr: codes.DeadlineExceeded},
+		{name: "handler error after deadline", err: errors.New("boom"), wantErr: codes.DeadlineExceeded},
+	}
+
+	d := &Deadlines{}
+	if err := d.Set(time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/calendar.API/createEvent"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				<-ctx.Done()
+				return tt.resp, tt.err
+			}
+			resp, err := d.UnaryServerInterceptor(context.Background(), nil, info, handler)
+			if code := status.Code(err); code != tt.wantErr {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantErr, err)
+			}
+			if tt.wantErr == codes.OK && resp != tt.resp {
+				t.Errorf("resp = %v, want %v", resp, tt.resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/lifecycle"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+//DedupCleanup удаляет из postgres просроченные записи об отправленных напоминаниях
+type DedupCleanup struct {
+	PSQL     postgres.PSQL
+	Logger   *zap.Logger
+	Interval time.Duration
+}
+
+//Run чистит записи раз в Interval до отмены ctx
+func (dc *DedupCleanup) Run(ctx context.Context) error {
+	for {
+		deleted, err := dc.PSQL.CleanupReminderDedup(ctx, time.Now())
+		if err != nil {
+			dc.Logger.Error(fmt.Sprintf("Dedup cleanup error %v", err))
+		} else if deleted > 0 {
+			dc.Logger.Info(fmt.Sprintf("Dedup cleanup deleted %v records", deleted))
+		}
+		if !lifecycle.Sleep(ctx, dc.Interval) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/lifecycle"
+	"calendar/internal/notification"
+	"calendar/internal/structs"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+//DeferredReminders доставляет напоминания, отложенные из-за тихих часов или суточного лимита
+type DeferredReminders struct {
+	PSQL        postgres.PSQL
+	Router      *notification.ReloadableRouter
+	Logger      *zap.Logger
+	Interval    time.Duration
+	BatchSize   int
+	RetryDelay  time.Duration
+	MaxAttempts int
+}
+
+//Run доставляет отложенные напоминания до отмены ctx. Начатый пакет доводится до конца, поэтому запросы к базе выполняются без отмены
+func (dr *DeferredReminders) Run(ctx context.Context) error {
+
+	//отложенное напоминание доставляется уже вне трассы, в которой его отложили
+	deliver := func(event structs.Event, reminder structs.Reminder) error {
+		return dr.Router.Notify(context.Background(), event, reminder)
+	}
+
+	for {
+		delivered, err := dr.PSQL.ProcessDeferredReminders(context.Background(), dr.BatchSize, time.Now(), dr.RetryDelay, dr.MaxAttempts, deliver)
+		if err != nil {
+			dr.Logger.Error(fmt.Sprintf("Deferred reminders error %v", err))
+		}
+		if delivered > 0 {
+			dr.Logger.Info(fmt.Sprintf("Delivered %v deferred reminders", delivered))
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		//если выбрали полный пакет, сразу берем следующий
+		if delivered < dr.BatchSize && !lifecycle.Sleep(ctx, dr.Interval) {
+			return nil
+		}
+	}
+}
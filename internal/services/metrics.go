@@ -0,0 +1,37 @@
+package services
+
+import (
+	"calendar/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	schedulerTicks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "scheduler",
+		Name:      "ticks_total",
+		Help:      "Checks of the database for due reminders.",
+	})
+
+	schedulerLastTick = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "scheduler",
+		Name:      "last_tick_timestamp_seconds",
+		Help:      "Unix time of the last finished check for due reminders.",
+	})
+
+	remindersScanned = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "scheduler",
+		Name:      "reminders_scanned_total",
+		Help:      "Due reminders found in the database.",
+	})
+
+	remindersPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "scheduler",
+		Name:      "reminders_published_total",
+		Help:      "Due reminders published to the queue.",
+	})
+)
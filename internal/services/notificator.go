@@ -0,0 +1,37 @@
+package services
+
+import (
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/notification"
+	"calendar/internal/structs"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+type Notificator struct {
+	Subscriber queue.Subscriber
+	Router     *notification.ReloadableRouter
+	Logger     *zap.Logger
+}
+
+//Run читает события из очереди и рассылает оповещения. Блокируется до закрытия Subscriber
+func (n *Notificator) Run() error {
+	return n.Subscriber.Receive(n.Notify)
+}
+
+//Notify рассылает оповещение о наступающей встрече. Остальные типы событий пропускаются
+func (n *Notificator) Notify(ctx context.Context, envelope structs.Envelope) error {
+	if envelope.Type != structs.ReminderDue {
+		return nil
+	}
+
+	//сообщения без данных о напоминании пришли от старого bgproc
+	reminder := structs.DefaultReminder(envelope.Event)
+	if envelope.Reminder != nil {
+		reminder = *envelope.Reminder
+	}
+
+	n.Logger.Info(fmt.Sprintf("Notify %v about %v", envelope.Event.Owner, envelope.Event.UUID))
+	return n.Router.Notify(ctx, envelope.Event, reminder)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/interfaces/queue"
+	"calendar/internal/lifecycle"
+	"calendar/internal/structs"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+//OutboxRelay публикует изменения событий из outbox, доставка at-least-once
+type OutboxRelay struct {
+	Publisher queue.Publisher
+	PSQL      postgres.PSQL
+	Logger    *zap.Logger
+	Interval  time.Duration
+	BatchSize int
+	Retention time.Duration
+	//PublishTimeout - сколько ждать подтверждения одной публикации, из него считается занятость пакета
+	PublishTimeout time.Duration
+}
+
+//Run публикует записи из outbox до отмены ctx. Начатый пакет доводится до конца, поэтому запросы к базе выполняются без отмены
+func (or *OutboxRelay) Run(ctx context.Context) error {
+
+	//запись помечается опубликованной после Publish, поэтому буферизовать нельзя
+	publish := func(envelope structs.Envelope) error {
+		return or.Publisher.Publish(queue.WithoutBuffering(context.Background()), envelope)
+	}
+
+	for {
+		published, err := or.PSQL.RelayOutbox(context.Background(), or.BatchSize, time.Now().UTC(), or.lease(), publish)
+		if err != nil {
+			or.Logger.Error(fmt.Sprintf("Outbox relay error %v", err))
+		}
+		if published > 0 {
+			or.Logger.Info(fmt.Sprintf("Outbox relay published %v records", published))
+		}
+
+		deleted, err := or.PSQL.CleanupOutbox(context.Background(), time.Now().UTC().Add(-or.Retention))
+		if err != nil {
+			or.Logger.Error(fmt.Sprintf("Outbox cleanup error %v", err))
+		} else if deleted > 0 {
+			or.Logger.Info(fmt.Sprintf("Outbox cleanup deleted %v records", deleted))
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		//если выбрали полный пакет, сразу берем следующий
+		if published < or.BatchSize && !lifecycle.Sleep(ctx, or.Interval) {
+			return nil
+		}
+	}
+}
+
+//lease - на сколько занимать пакет записей: пакет публикуется по одной записи, каждая не дольше PublishTimeout
+func (or *OutboxRelay) lease() time.Duration {
+	return time.Duration(or.BatchSize)*or.PublishTimeout + or.Interval
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"calendar/internal/config"
+	"calendar/internal/interfaces/memqueue"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/notification"
+	"calendar/internal/structs"
+	"context"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+//TestReminderPipeline проходит путь напоминания от BackgroundProcessor через очередь в памяти
+//до доставки Notificator в консольный канал без брокера и базы
+func TestReminderPipeline(t *testing.T) {
+	logger := zap.NewNop()
+	output := filepath.Join(t.TempDir(), "console.log")
+
+	router, err := notification.NewReloadableRouter(logger, config.NotificationConfig{
+		Channels:        []string{notification.ChannelConsole},
+		DefaultChannels: []string{notification.ChannelConsole},
+		Locale:          "en",
+		TimeZone:        "UTC",
+		Locales:         "../../configs/locales",
+		Dedup:           config.DedupConfig{Backend: notification.DedupMemory, Size: 100, TTL: time.Hour, Lease: time.Minute},
+		Console:         config.ConsoleConfig{Output: output},
+	}, notification.Stores{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := memqueue.NewQueue(logger, 10)
+	notificator := Notificator{Subscriber: queue, Router: router, Logger: logger}
+	done := make(chan error, 1)
+	go func() {
+		done <- notificator.Run()
+	}()
+
+	bp := &BackgroundProcessor{Publisher: queue, Logger: logger}
+	start := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	reminder := func(uuid string, header string) postgres.PSQLReminder {
+		event := structs.Event{UUID: uuid, Header: header, Owner: "owner", DateTime: start, EventDurationStart: start, EventDurationStop: start.Add(time.Hour)}
+		return postgres.PSQLReminder{Event: event, Reminder: structs.DefaultReminder(event)}
+	}
+
+	//повтор того же напоминания отсекается, изменения событий нотификатор пропускает
+	for _, r := range []postgres.PSQLReminder{reminder("1", "first meeting"), reminder("1", "first meeting")} {
+		err = bp.publish(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = queue.Publish(context.Background(), structs.NewEnvelope(structs.EventCreated, reminder("3", "created event").Event))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bp.publish(reminder("2", "second meeting"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//очередь обрабатывается по порядку, поэтому после второго напоминания обработаны и все предыдущие сообщения
+	text := waitForOutput(t, output, "second meeting")
+	if n := strings.Count(text, "first meeting"); n != 1 {
+		t.Errorf("first reminder delivered %v times, want 1:\n%v", n, text)
+	}
+	if strings.Contains(text, "created event") {
+		t.Errorf("event change was delivered as a reminder:\n%v", text)
+	}
+
+	queue.Close()
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notificator did not stop after queue close")
+	}
+}
+
+func waitForOutput(t *testing.T, path string, substr string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), substr) {
+			return string(data)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%q not delivered, output:\n%s", substr, data)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,57 @@
+package structs
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+//типы доменных событий, они же ключи маршрутизации в topic exchange
+const (
+	EventCreated = "event.created"
+	EventUpdated = "event.updated"
+	EventDeleted = "event.deleted"
+	ReminderDue  = "reminder.due"
+)
+
+//EnvelopeVersion - текущая версия формата конверта
+const EnvelopeVersion = 1
+
+//Envelope - конверт для всех сообщений, уходящих в брокер
+type Envelope struct {
+	Type       string    `json:"type"`               //тип события (ключ маршрутизации)
+	Version    int       `json:"version"`            //версия формата конверта
+	MessageId  string    `json:"message_id"`         //уникальный ID сообщения
+	OccurredAt time.Time `json:"occurred_at"`        //когда произошло событие
+	Event      Event     `json:"event"`              //событие календаря
+	Reminder   *Reminder `json:"reminder,omitempty"` //вхождение и смещение, только для reminder.due
+}
+
+func NewEnvelope(eventType string, event Event) Envelope {
+	return Envelope{
+		Type:       eventType,
+		Version:    EnvelopeVersion,
+		MessageId:  NewMessageId(),
+		OccurredAt: time.Now().UTC(),
+		Event:      event,
+	}
+}
+
+//NewReminderEnvelope создает конверт reminder.due для напоминания об одном вхождении события
+func NewReminderEnvelope(event Event, reminder Reminder) Envelope {
+	envelope := NewEnvelope(ReminderDue, event)
+	envelope.Reminder = &reminder
+	return envelope
+}
+
+//NewMessageId генерирует случайный UUID версии 4
+func NewMessageId() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,18 @@
+package structs
+
+import "time"
+
+//NotificationPreferences - настройки оповещений владельца. Пустые поля берутся из настроек по умолчанию
+type NotificationPreferences struct {
+	Owner           string    `db:"owner" json:"owner"`                         //владелец
+	Channels        []string  `db:"channels" json:"channels"`                   //включенные каналы
+	Email           string    `db:"email" json:"email"`                         //адрес для канала email
+	WebhookURL      string    `db:"webhook_url" json:"webhook_url"`             //адрес для канала webhook
+	Locale          string    `db:"locale" json:"locale"`                       //язык оповещений
+	TimeZone        string    `db:"timezone" json:"timezone"`                   //часовой пояс владельца (IANA)
+	ReminderOffsets []int32   `db:"reminder_offsets" json:"reminder_offsets"`   //за сколько минут до начала напоминать, если у события не задано
+	QuietHoursStart string    `db:"quiet_hours_start" json:"quiet_hours_start"` //начало тихих часов, ЧЧ:ММ в часовом поясе владельца
+	QuietHoursEnd   string    `db:"quiet_hours_end" json:"quiet_hours_end"`     //конец тихих часов
+	DailyCap        int32     `db:"daily_cap" json:"daily_cap"`                 //не больше оповещений в сутки, 0 - без ограничения
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`               //когда изменены
+}
@@ -0,0 +1,59 @@
+package structs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//статусы доставки напоминания
+const (
+	ReminderSent     = "sent"
+	ReminderFailed   = "failed"
+	ReminderBounced  = "bounced"
+	ReminderDeferred = "deferred"
+	ReminderDropped  = "dropped" //отложить можно было только на время после начала события
+)
+
+//ErrReminderSending - напоминание сейчас отправляет другой обработчик. Если он упал, запись истечет через lease,
+//поэтому сообщение нужно повторить позже, а не считать доставленным
+var ErrReminderSending = errors.New("reminder is being sent by another notifier")
+
+//Reminder - напоминание об одном вхождении события за Offset минут до его начала
+type Reminder struct {
+	Occurrence time.Time `json:"occurrence"` //начало вхождения события
+	Offset     int32     `json:"offset"`     //за сколько минут до начала
+}
+
+//DefaultReminder описывает напоминание для сообщений без данных о напоминании:
+//единственное вхождение события и смещение из mailingduration
+func DefaultReminder(event Event) Reminder {
+	return Reminder{Occurrence: event.EventDurationStart, Offset: event.MailingDuration}
+}
+
+//NotifyAt - когда напоминание должно быть доставлено
+func (r Reminder) NotifyAt() time.Time {
+	return r.Occurrence.Add(-time.Duration(r.Offset) * time.Minute)
+}
+
+//ReminderId - детерминированный ID напоминания по каналу. Повторная доставка того же
+//напоминания по тому же каналу всегда дает тот же ID
+func ReminderId(eventUUID string, reminder Reminder, channel string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v|%v|%v|%v", eventUUID, reminder.Occurrence.UTC().Format(time.RFC3339), reminder.Offset, channel)))
+	return hex.EncodeToString(sum[:16])
+}
+
+//ReminderDelivery - запись журнала доставки напоминания по одному каналу
+type ReminderDelivery struct {
+	Id          int64      `db:"id" json:"id"`                     //ID записи
+	ReminderId  string     `db:"reminder_id" json:"reminder_id"`   //детерминированный ID напоминания по каналу
+	EventUUID   string     `db:"event_uuid" json:"event_uuid"`     //ID события
+	Owner       string     `db:"owner" json:"owner"`               //владелец события
+	Channel     string     `db:"channel" json:"channel"`           //канал, пустой для отложенного напоминания
+	Status      string     `db:"status" json:"status"`             //sent, failed, bounced или deferred
+	Error       *string    `db:"error" json:"error"`               //текст ошибки или причина переноса
+	AttemptedAt time.Time  `db:"attempted_at" json:"attempted_at"` //когда была попытка
+	DeferredTo  *time.Time `db:"deferred_to" json:"deferred_to"`   //на когда перенесено
+}
@@ -11,4 +11,5 @@ type Event struct {
 	MailingDuration    int32     `db:"mailingduration" json:"mailing_duration"`         //за сколько нужно выслать оповещение (в минутах)
 	EventDurationStart time.Time `db:"eventduration_start" json:"event_duration_start"` //длительность события начало
 	EventDurationStop  time.Time `db:"eventduration_stop" json:"event_duration_stop"`   //длительность события конец
+	Urgent             bool      `db:"urgent" json:"urgent"`                            //срочное, оповещение не откладывается
 }
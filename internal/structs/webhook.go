@@ -0,0 +1,35 @@
+package structs
+
+import "time"
+
+//статусы доставки вебхука
+const (
+	WebhookPending = "pending"
+	WebhookSent    = "sent"
+	WebhookFailed  = "failed"
+)
+
+type WebhookSubscription struct {
+	UUID       string    `db:"uuid" json:"uuid"`               //ID подписки
+	Owner      string    `db:"owner" json:"owner"`             //владелец подписки
+	URL        string    `db:"url" json:"url"`                 //куда отправлять
+	Secret     string    `db:"secret" json:"-"`                //ключ для подписи HMAC-SHA256
+	EventTypes []string  `db:"event_types" json:"event_types"` //фильтр по типам событий, пустой - все типы
+	Active     bool      `db:"active" json:"active"`           //включена ли подписка
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`   //когда создана
+}
+
+type WebhookDelivery struct {
+	Id               int64      `db:"id" json:"id"`
+	SubscriptionUUID string     `db:"subscription_uuid" json:"subscription_uuid"`
+	MessageId        string     `db:"message_id" json:"message_id"`           //ID сообщения из конверта
+	EventType        string     `db:"event_type" json:"event_type"`           //тип события
+	Payload          []byte     `db:"payload" json:"-"`                       //тело запроса
+	Status           string     `db:"status" json:"status"`                   //pending, sent или failed
+	Attempts         int32      `db:"attempts" json:"attempts"`               //сколько было попыток
+	ResponseCode     *int32     `db:"response_code" json:"response_code"`     //HTTP код последней попытки
+	Error            *string    `db:"error" json:"error"`                     //ошибка последней попытки
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`           //когда поставлена в очередь
+	NextAttemptAt    time.Time  `db:"next_attempt_at" json:"next_attempt_at"` //когда следующая попытка
+	LastAttemptAt    *time.Time `db:"last_attempt_at" json:"last_attempt_at"` //когда была последняя попытка
+}
@@ -0,0 +1,136 @@
+package webhooks
+
+import (
+	"bytes"
+	"calendar/internal/interfaces/postgres"
+	"calendar/internal/lifecycle"
+	"calendar/internal/structs"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//Dispatcher принимает события из брокера, ставит их в очередь доставки подписчикам
+//и отправляет подписанные запросы с экспоненциальными повторами
+type Dispatcher struct {
+	PSQL       postgres.PSQL
+	Logger     *zap.Logger
+	Client     *http.Client
+	Interval   time.Duration //как часто проверять очередь доставок
+	BatchSize  int           //сколько доставок брать за раз
+	RetryDelay time.Duration //задержка перед первым повтором, дальше удваивается
+	MaxDelay   time.Duration //максимальная задержка между повторами
+	MaxAge     time.Duration //после этого доставка считается неудачной
+}
+
+//Enqueue - обработчик сообщений из брокера, создает доставки для подходящих подписок
+func (d *Dispatcher) Enqueue(ctx context.Context, envelope structs.Envelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	created, err := d.PSQL.EnqueueWebhookDeliveries(ctx, envelope.MessageId, envelope.Type, payload, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if created > 0 {
+		d.Logger.Info(fmt.Sprintf("Queued %v webhook deliveries for %v %v", created, envelope.Type, envelope.MessageId))
+	}
+	return nil
+}
+
+//Run отправляет доставки до отмены ctx. Начатый пакет доотправляется, поэтому запросы к базе выполняются без отмены
+func (d *Dispatcher) Run(ctx context.Context) error {
+	for {
+		processed, err := d.PSQL.ProcessWebhookDeliveries(context.Background(), d.BatchSize, time.Now().UTC(), d.lease(), d.deliver)
+		if err != nil {
+			d.Logger.Error(fmt.Sprintf("Webhook deliveries error %v", err))
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if processed < d.BatchSize && !lifecycle.Sleep(ctx, d.Interval) {
+			return nil
+		}
+	}
+}
+
+//lease - на сколько занимать пакет доставок: пакет отправляется по одной доставке, каждая не дольше таймаута клиента
+func (d *Dispatcher) lease() time.Duration {
+	return time.Duration(d.BatchSize)*d.Client.Timeout + d.Interval
+}
+
+//deliver делает одну попытку доставки и возвращает ее результат для журнала
+func (d *Dispatcher) deliver(delivery structs.WebhookDelivery, url string, secret string) structs.WebhookDelivery {
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	code, err := d.post(delivery, url, secret, now)
+	if code != 0 {
+		delivery.ResponseCode = &code
+	}
+	if err == nil {
+		delivery.Status = structs.WebhookSent
+		delivery.Error = nil
+		d.Logger.Info(fmt.Sprintf("Webhook %v delivered to %v", delivery.MessageId, url))
+		return delivery
+	}
+
+	message := err.Error()
+	delivery.Error = &message
+
+	next := now.Add(backoff(d.RetryDelay, d.MaxDelay, delivery.Attempts))
+	if next.Sub(delivery.CreatedAt) > d.MaxAge {
+		delivery.Status = structs.WebhookFailed
+		d.Logger.Error(fmt.Sprintf("Webhook %v to %v failed after %v attempts: %v", delivery.MessageId, url, delivery.Attempts, err))
+		return delivery
+	}
+
+	delivery.NextAttemptAt = next
+	d.Logger.Error(fmt.Sprintf("Webhook %v to %v attempt %v failed, retry at %v: %v", delivery.MessageId, url, delivery.Attempts, next, err))
+	return delivery
+}
+
+func (d *Dispatcher) post(delivery structs.WebhookDelivery, url string, secret string, now time.Time) (int32, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(secret, now, delivery.Payload))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.MessageId)
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return int32(resp.StatusCode), fmt.Errorf("endpoint responded with %v", resp.Status)
+	}
+	return int32(resp.StatusCode), nil
+}
+
+//backoff - задержка перед следующей попыткой: base*2^(attempt-1), но не больше max
+func backoff(base time.Duration, max time.Duration, attempt int32) time.Duration {
+	delay := base
+	for i := int32(1); i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"calendar/internal/structs"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int32
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{6, 32 * time.Second},
+		{7, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := backoff(time.Second, time.Minute, tt.attempt); got != tt.want {
+			t.Errorf("backoff attempt %v = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+//TestDeliver проверяет подпись запроса и судьбу доставки: отправлена, повтор по backoff или отказ после MaxAge
+func TestDeliver(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := Verify("topsecret", r.Header.Get(HeaderSignature), r.Header.Get(HeaderTimestamp), body, time.Minute)
+		if err != nil || r.Header.Get(HeaderEvent) != structs.EventCreated || r.Header.Get(HeaderDelivery) != "message" {
+			t.Errorf("bad request: %v %v", err, r.Header)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	d := &Dispatcher{
+		Logger:     zap.NewNop(),
+		Client:     server.Client(),
+		RetryDelay: time.Minute,
+		MaxDelay:   time.Hour,
+		MaxAge:     24 * time.Hour,
+	}
+
+	tests := []struct {
+		name       string
+		status     int
+		attempts   int32
+		age        time.Duration
+		wantStatus string
+		wantDelay  time.Duration
+	}{
+		{"sent", http.StatusOK, 0, 0, structs.WebhookSent, 0},
+		{"first retry", http.StatusInternalServerError, 0, 0, structs.WebhookPending, time.Minute},
+		{"backoff doubles", http.StatusServiceUnavailable, 3, time.Hour, structs.WebhookPending, 8 * time.Minute},
+		{"backoff is capped", http.StatusServiceUnavailable, 10, 2 * time.Hour, structs.WebhookPending, time.Hour},
+		{"fails after max age", http.StatusInternalServerError, 10, 23*time.Hour + 30*time.Minute, structs.WebhookFailed, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			now := time.Now().UTC()
+			delivery := d.deliver(structs.WebhookDelivery{
+				MessageId: "message",
+				EventType: structs.EventCreated,
+				Payload:   []byte(`{"type":"event.created"}`),
+				Status:    structs.WebhookPending,
+				Attempts:  tt.attempts,
+				CreatedAt: now.Add(-tt.age),
+			}, server.URL, "topsecret")
+
+			if delivery.Status != tt.wantStatus {
+				t.Fatalf("status %v, want %v", delivery.Status, tt.wantStatus)
+			}
+			if delivery.Attempts != tt.attempts+1 || delivery.ResponseCode == nil || *delivery.ResponseCode != int32(tt.status) {
+				t.Errorf("attempts %v, response %v", delivery.Attempts, delivery.ResponseCode)
+			}
+			if tt.wantStatus == structs.WebhookPending {
+				if delay := delivery.NextAttemptAt.Sub(*delivery.LastAttemptAt); delay != tt.wantDelay {
+					t.Errorf("next attempt in %v, want %v", delay, tt.wantDelay)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//заголовки исходящего запроса
+const (
+	HeaderSignature = "X-Calendar-Signature"
+	HeaderTimestamp = "X-Calendar-Timestamp"
+	HeaderEvent     = "X-Calendar-Event"
+	HeaderDelivery  = "X-Calendar-Delivery"
+)
+
+//Sign считает подпись HMAC-SHA256 от "<timestamp>.<body>".
+//Получатель должен проверить подпись и отбросить запросы со старым timestamp, чтобы защититься от повторов
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+//Verify проверяет подпись и что timestamp не старше tolerance
+func Verify(secret string, signature string, timestamp string, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad timestamp %v", timestamp)
+	}
+	ts := time.Unix(unix, 0)
+	if time.Since(ts) > tolerance {
+		return fmt.Errorf("timestamp %v is too old", ts)
+	}
+	if !hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
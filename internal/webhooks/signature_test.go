@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"type":"event.created"}`, "sha256=a3c07b87c989924031ed00ae9327b07e068e7c02d4db66023cbadee2ddbc6e8a"},
+		{"", "sha256=1736616ca502dd0795dd33e742aba65214c255d6064bb6035e7b37d18983438e"},
+	}
+	for _, tt := range tests {
+		if got := Sign("topsecret", ts, []byte(tt.body)); got != tt.want {
+			t.Errorf("Sign(%q) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"event.created"}`)
+	now := time.Now()
+	fresh := now.Add(-10 * time.Second)
+	stale := now.Add(-10 * time.Minute)
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		wantErr   string
+	}{
+		{"valid", Sign("topsecret", fresh, body), strconv.FormatInt(fresh.Unix(), 10), body, ""},
+		{"fixed vector", "sha256=a3c07b87c989924031ed00ae9327b07e068e7c02d4db66023cbadee2ddbc6e8a", "1700000000", body, "too old"},
+		{"stale", Sign("topsecret", stale, body), strconv.FormatInt(stale.Unix(), 10), body, "too old"},
+		{"wrong secret", Sign("other", fresh, body), strconv.FormatInt(fresh.Unix(), 10), body, "signature mismatch"},
+		{"tampered body", Sign("topsecret", fresh, body), strconv.FormatInt(fresh.Unix(), 10), []byte(`{"type":"event.deleted"}`), "signature mismatch"},
+		//подпись привязана к timestamp, подменить его нельзя
+		{"replayed with new timestamp", Sign("topsecret", stale, body), strconv.FormatInt(fresh.Unix(), 10), body, "signature mismatch"},
+		{"bad timestamp", Sign("topsecret", fresh, body), "yesterday", body, "bad timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify("topsecret", tt.signature, tt.timestamp, tt.body, 5*time.Minute)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}